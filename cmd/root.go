@@ -38,6 +38,7 @@ const (
 	useMiddlewareFlag  = "middleware"
 	purgeCacheFlag     = "purge-cache"
 	softExitFlag       = "soft-exit"
+	submodulesFlag     = "recurse-submodules"
 	orgFlag            = "organization"
 	excludeReposFlag   = "exclude-repos"
 	pageCountFlag      = "page-count"
@@ -139,6 +140,7 @@ func init() {
 		orgFlagUsage                 = "used when using organization github app"
 		excludeReposFlagUsage        = "used to exclude repos from gathering on org mode"
 		softExitUsage                = "used on cleanup to exit soft without crashing"
+		submodulesUsage              = "whether to clone git submodules recursively & collect SBOMs from their contents (default: false)"
 		useMiddlewareUsage           = "used to change the dependency-track url to your own supplied API for SBOM consumption"
 		pageCountFlagUsage           = "used with pagination per org to specify slice of pages"
 		pageIndexFlagUsage           = "used with pagination per org to specify index of how many slices"
@@ -160,6 +162,7 @@ func init() {
 
 	rootCmd.PersistentFlags().BoolP(purgeCacheFlag, "p", false, purgeCacheUsage)
 	rootCmd.PersistentFlags().BoolP(softExitFlag, "s", false, softExitUsage)
+	rootCmd.PersistentFlags().Bool(submodulesFlag, false, submodulesUsage)
 
 	rootCmd.PersistentFlags().StringP(orgFlag, "g", "", orgFlagUsage)
 	rootCmd.PersistentFlags().StringSlice(excludeReposFlag, nil, excludeReposFlagUsage)
@@ -59,6 +59,14 @@ func createAppFromCLI(cmd *cobra.Command, verbose bool) (*app.App, error) {
 		options = append(options, app.WithSoftExit())
 	}
 
+	recurseSubmodules, err := cmd.Flags().GetBool(submodulesFlag)
+	if err != nil {
+		return nil, fmt.Errorf(errTemplate, submodulesFlag)
+	}
+	if recurseSubmodules {
+		options = append(options, app.WithSubmodules())
+	}
+
 	if uploadToDependencyTrack {
 		classifier, err := cmd.Flags().GetString(classifierFlag)
 		if err != nil {
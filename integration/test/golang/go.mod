@@ -0,0 +1,27 @@
+module github.com/vinted/sample-service
+
+go 1.21
+
+require (
+	github.com/sirupsen/logrus v1.9.3
+	github.com/spf13/cobra v1.8.0
+	github.com/vinted/internal-lib v0.3.1
+)
+
+require (
+	github.com/inconshreveable/mousetrap v1.1.0 // indirect
+	github.com/spf13/pflag v1.0.5 // indirect
+	golang.org/x/sys v0.0.0-20220715151400-c0bba94af5f8 // indirect
+	gopkg.in/yaml.v2 v2.2.7 // indirect
+)
+
+replace github.com/vinted/internal-lib => ../internal-lib
+
+replace golang.org/x/sys v0.0.0-20220715151400-c0bba94af5f8 => golang.org/x/sys v0.15.0
+
+exclude gopkg.in/yaml.v2 v2.2.7
+
+retract (
+	v1.0.1 // Published accidentally.
+	[v1.1.0, v1.1.5] // Contains broken dependencies.
+)
@@ -0,0 +1,12 @@
+module github.com/vinted/vendored-service
+
+go 1.21
+
+require (
+	github.com/pkg/errors v0.9.1
+	golang.org/x/sys v0.0.0-20220715151400-c0bba94af5f8
+)
+
+require github.com/kr/text v0.2.0 // indirect
+
+replace golang.org/x/sys v0.0.0-20220715151400-c0bba94af5f8 => golang.org/x/sys v0.15.0
@@ -0,0 +1,33 @@
+terraform {
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "~> 5.0"
+    }
+  }
+}
+
+module "vpc" {
+  source  = "terraform-aws-modules/vpc/aws"
+  version = "5.1.2"
+
+  name = "main"
+  cidr = "10.0.0.0/16"
+}
+
+module "eks" {
+  source  = "terraform-aws-modules/eks/aws"
+  version = "~> 19.0"
+}
+
+module "network" {
+  source = "git::https://git.acme.com/platform/network.git//modules/subnets?ref=v1.4.0"
+}
+
+module "labels" {
+  source = "github.com/cloudposse/terraform-null-label?ref=0.25.0"
+}
+
+module "local" {
+  source = "./modules/local"
+}
@@ -30,7 +30,7 @@ type App struct {
 	tags, excludedRepos                          []string
 	githubUsername, githubAPIToken, organization string // TODO Move later on to a separate GitHub client
 	dependencyTrackClient                        *dtrack.DependencyTrackClient
-	purgeCache, softExit                         bool
+	purgeCache, softExit, recurseSubmodules      bool
 	pagesCount, pagesIndex                       int64
 }
 
@@ -44,7 +44,7 @@ type options struct {
 	tags, excludedRepos                                         []string
 	githubUsername, githubAPIToken, organization, middlewareUrl string // TODO Move later on to a separate GitHub client
 	dependencyTrackClient                                       *dtrack.DependencyTrackClient
-	purgeCache, softExit                                        bool
+	purgeCache, softExit, recurseSubmodules                     bool
 	pageCount, pageIndex                                        int64
 }
 
@@ -115,6 +115,13 @@ func WithSoftExit() Option {
 	}
 }
 
+func WithSubmodules() Option {
+	return func(options *options) error {
+		options.recurseSubmodules = true
+		return nil
+	}
+}
+
 func WithTags(tags []string) Option {
 	return func(options *options) error {
 		options.tags = tags
@@ -165,6 +172,7 @@ func New(outputFile string, opts ...Option) (*App, error) {
 
 	app.purgeCache = options.purgeCache
 	app.softExit = options.softExit
+	app.recurseSubmodules = options.recurseSubmodules
 	app.dependencyTrackClient = options.dependencyTrackClient
 	app.pagesCount = options.pageCount
 	app.pagesIndex = options.pageIndex
@@ -343,10 +351,15 @@ func (a App) sbomsFromRepositoryInternal(ctx context.Context, repositoryURL stri
 		}
 	}
 
+	var cloneOptions []repository.Option
+	if a.recurseSubmodules {
+		cloneOptions = append(cloneOptions, repository.WithSubmodules())
+	}
+
 	repo, err = repository.New(ctx, repositoryURL, repository.Credentials{
 		Username:    a.githubUsername,
 		AccessToken: a.githubAPIToken,
-	})
+	}, cloneOptions...)
 	if errors.Is(err, context.Canceled) {
 		return
 	}
@@ -369,7 +382,7 @@ func (a App) sbomsFromRepositoryInternal(ctx context.Context, repositoryURL stri
 		repo, err = repository.New(ctx, repositoryURL, repository.Credentials{
 			Username:    a.githubUsername,
 			AccessToken: a.githubAPIToken,
-		})
+		}, cloneOptions...)
 		if err != nil {
 			log.WithError(err).Errorf("could not fetch after regenerated token %s", repositoryURL)
 			return
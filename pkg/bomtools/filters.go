@@ -14,9 +14,11 @@ func FilterOutByScope(sbom *cdx.BOM, scope cdx.Scope) *cdx.BOM {
 	}
 	// Filter out each component that matches the supplied scope
 	requiredComponents := make([]cdx.Component, 0, len(*sbom.Components))
+	removedRefs := make(map[string]bool)
 
 	for _, c := range *sbom.Components {
 		if c.Scope == scope {
+			removedRefs[c.BOMRef] = true
 			continue
 		}
 		requiredComponents = append(requiredComponents, c)
@@ -24,6 +26,35 @@ func FilterOutByScope(sbom *cdx.BOM, scope cdx.Scope) *cdx.BOM {
 
 	sbom.Components = &requiredComponents
 
+	return pruneDependencies(sbom, removedRefs)
+}
+
+// pruneDependencies removes references to filtered out components from the SBOM dependency graph
+func pruneDependencies(sbom *cdx.BOM, removedRefs map[string]bool) *cdx.BOM {
+	delete(removedRefs, "")
+	if sbom.Dependencies == nil || len(removedRefs) == 0 {
+		return sbom
+	}
+
+	dependencies := make([]cdx.Dependency, 0, len(*sbom.Dependencies))
+	for _, d := range *sbom.Dependencies {
+		if removedRefs[d.Ref] {
+			continue
+		}
+		if d.Dependencies != nil {
+			dependsOn := make([]string, 0, len(*d.Dependencies))
+			for _, ref := range *d.Dependencies {
+				if !removedRefs[ref] {
+					dependsOn = append(dependsOn, ref)
+				}
+			}
+			d.Dependencies = &dependsOn
+		}
+		dependencies = append(dependencies, d)
+	}
+
+	sbom.Dependencies = &dependencies
+
 	return sbom
 }
 
@@ -34,9 +65,11 @@ func FilterOutComponentsWithoutAType(sbom *cdx.BOM) *cdx.BOM {
 	}
 
 	requiredComponents := make([]cdx.Component, 0, len(*sbom.Components))
+	removedRefs := make(map[string]bool)
 
 	for _, c := range *sbom.Components {
 		if c.Type == "" { // Every component must have a valid type
+			removedRefs[c.BOMRef] = true
 			continue
 		}
 		requiredComponents = append(requiredComponents, c)
@@ -44,7 +77,7 @@ func FilterOutComponentsWithoutAType(sbom *cdx.BOM) *cdx.BOM {
 
 	sbom.Components = &requiredComponents
 
-	return sbom
+	return pruneDependencies(sbom, removedRefs)
 }
 
 // StripCPEsFromComponents Remove CPEs from all SBOM components
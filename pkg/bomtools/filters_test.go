@@ -41,6 +41,24 @@ func TestFilterOptionalDependencies(t *testing.T) {
 		assert.Equal(t, expectedPURLs, actualPURLs)
 	})
 
+	t.Run("prune dependency graph of filtered out components", func(t *testing.T) {
+		bom := cdx.NewBOM()
+		bom.Components = &[]cdx.Component{
+			{BOMRef: "pkg:npm/jest@29.5.0", Type: cdx.ComponentTypeLibrary, Scope: cdx.ScopeOptional},
+			{BOMRef: "pkg:npm/lodash@4.17.21", Type: cdx.ComponentTypeLibrary, Scope: cdx.ScopeRequired},
+			{BOMRef: "pkg:npm/react@18.2.0", Type: cdx.ComponentTypeLibrary},
+		}
+		bom.Dependencies = &[]cdx.Dependency{
+			{Ref: "pkg:npm/jest@29.5.0", Dependencies: &[]string{"pkg:npm/lodash@4.17.21"}},
+			{Ref: "pkg:npm/react@18.2.0", Dependencies: &[]string{"pkg:npm/jest@29.5.0", "pkg:npm/lodash@4.17.21"}},
+		}
+
+		got := FilterOutByScope(bom, cdx.ScopeOptional)
+		assert.Equal(t, &[]cdx.Dependency{
+			{Ref: "pkg:npm/react@18.2.0", Dependencies: &[]string{"pkg:npm/lodash@4.17.21"}},
+		}, got.Dependencies)
+	})
+
 	t.Run("return an error when trying to filter dependencies from malformed BOMs", func(t *testing.T) {
 		assert.Nil(t, FilterOutByScope(nil, cdx.ScopeOptional)) // Return nil when trying to filter from nil
 
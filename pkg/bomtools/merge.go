@@ -15,6 +15,19 @@ import (
 
 var ErrNoBOMsToMerge = errors.New("merge_boms: can't merge empty list of BOMs")
 
+var pypiNameSeparatorsRe = regexp.MustCompile(`[-_.]+`)
+
+/*
+NormalizePyPIName normalizes Python package names as described in PEP 503. E.g. given the following input:
+
+	Typing_Extensions
+
+this function will return: typing-extensions
+*/
+func NormalizePyPIName(name string) string {
+	return strings.ToLower(pypiNameSeparatorsRe.ReplaceAllString(strings.TrimSpace(name), "-"))
+}
+
 type MergeSBOMParam struct {
 	SBOMs         []*cdx.BOM
 	OptionalParam string // Replace MyType with the type of your optional parameter
@@ -42,6 +55,82 @@ func stripChecksumIfExists(candidate string) string {
 	return candidate
 }
 
+/*
+normalizePyPIPURL normalizes package names of pkg:pypi PURLs as described in PEP 503. Different tools
+report Python package names as they were spelled in the package files, for example:
+pkg:pypi/Django@4.2.1 or pkg:pypi/typing_extensions@4.7.1
+
+These must be normalized to pkg:pypi/django@4.2.1 & pkg:pypi/typing-extensions@4.7.1 for components
+coming from different collectors to be merged correctly. Other PURLs are returned as is.
+*/
+func normalizePyPIPURL(purl string) string {
+	const pypiPrefix = "pkg:pypi/"
+	if !strings.HasPrefix(purl, pypiPrefix) {
+		return purl
+	}
+
+	rest := strings.TrimPrefix(purl, pypiPrefix)
+	end := strings.IndexAny(rest, "@?#")
+	if end == -1 {
+		end = len(rest)
+	}
+	return pypiPrefix + NormalizePyPIName(rest[:end]) + rest[end:]
+}
+
+/*
+identifyingQualifiers lists PURL qualifiers, by PURL type, which tell apart different artifacts of the same
+package version. E.g. gems built for different platforms or charts served by different Helm repositories. Other
+qualifiers, e.g. repository_url of Maven artifacts, differ between tools reporting the same artifact, so they're
+dropped in order for components to be merged correctly.
+*/
+var identifyingQualifiers = map[string][]string{
+	"conan": {"user", "channel"},
+	"conda": {"build", "channel", "subdir"},
+	"gem":   {"platform"},
+	"helm":  {"repository_url"},
+	"maven": {"classifier", "type"},
+}
+
+/*
+verbatimVersionTypes lists PURL types, whose versions are tags or refs rather than semantic versions. Those
+identify artifacts as they are, so a leading v isn't stripped. E.g. pkg:docker/node@v18 & pkg:docker/node@18
+are different images.
+*/
+var verbatimVersionTypes = map[string]bool{
+	"docker":        true,
+	"githubactions": true,
+	"helm":          true,
+	"terraform":     true,
+}
+
+/*
+stripQualifiers rebuilds a parsed PURL keeping only its identifying qualifiers & its subpath. Maven type
+qualifiers are kept only for artifacts other than jars, because tools disagree on reporting the default type.
+*/
+func stripQualifiers(wrapped *url.URL) string {
+	purl := wrapped.Scheme + ":" + wrapped.Opaque
+	purlType, _, _ := strings.Cut(wrapped.Opaque, "/")
+
+	qualifiers := wrapped.Query()
+	var kept []string
+	for _, key := range identifyingQualifiers[purlType] {
+		value := qualifiers.Get(key)
+		if value == "" || (purlType == "maven" && key == "type" && value == "jar") {
+			continue
+		}
+		kept = append(kept, key+"="+strings.ReplaceAll(url.QueryEscape(value), "+", "%20"))
+	}
+	if len(kept) > 0 {
+		sort.Strings(kept)
+		purl += "?" + strings.Join(kept, "&")
+	}
+	if wrapped.Fragment != "" {
+		purl += "#" + wrapped.EscapedFragment()
+	}
+
+	return purl
+}
+
 func normalizePURLs(bom *cdx.BOM) *cdx.BOM {
 	if bom.Components == nil || len(*bom.Components) == 0 {
 		return bom
@@ -63,11 +152,12 @@ func normalizePURLs(bom *cdx.BOM) *cdx.BOM {
 		if encodedPurlRe.MatchString(normalizedPURL) {
 			normalizedPURL = strings.Replace(normalizedPURL, "%40", "", 1)
 		}
-		if versionedPurlRe.MatchString(normalizedPURL) {
+		purlType, _, _ := strings.Cut(strings.TrimPrefix(normalizedPURL, "pkg:"), "/")
+		if versionedPurlRe.MatchString(normalizedPURL) && !verbatimVersionTypes[purlType] {
 			normalizedPURL = strings.Replace(normalizedPURL, "@v", "@", 1)
 		}
 
-		normalizedPURL = stripChecksumIfExists(normalizedPURL)
+		normalizedPURL = normalizePyPIPURL(stripChecksumIfExists(normalizedPURL))
 
 		wrapped, err := url.Parse(normalizedPURL)
 		if err != nil {
@@ -75,7 +165,7 @@ func normalizePURLs(bom *cdx.BOM) *cdx.BOM {
 			normalized = append(normalized, c)
 			continue
 		}
-		c.PackageURL = wrapped.Scheme + ":" + wrapped.Opaque
+		c.PackageURL = stripQualifiers(wrapped)
 		normalized = append(normalized, c)
 	}
 	bom.Components = &normalized
@@ -226,6 +316,89 @@ func mergeAllByPURL(component *cdx.Component, allComponents []*cdx.Component) *c
 	return mergedComponent
 }
 
+/*
+normalizeDependencyRefs returns the dependency graph of a BOM with references rewritten to normalized PURLs.
+Library components are merged by their PURL & their BOM refs are replaced by PURLs during the merge, so
+dependency references must follow the same rules. Call this function after normalizing BOM PURLs.
+*/
+func normalizeDependencyRefs(bom *cdx.BOM) []cdx.Dependency {
+	if bom.Dependencies == nil || len(*bom.Dependencies) == 0 {
+		return nil
+	}
+
+	refsToPURLs := make(map[string]string)
+	if bom.Components != nil {
+		for _, c := range *bom.Components {
+			if c.Type == cdx.ComponentTypeLibrary && c.BOMRef != "" {
+				refsToPURLs[c.BOMRef] = c.PackageURL
+			}
+		}
+	}
+	normalize := func(ref string) string {
+		if purl, ok := refsToPURLs[ref]; ok {
+			return purl
+		}
+		return ref
+	}
+
+	normalized := make([]cdx.Dependency, 0, len(*bom.Dependencies))
+	for _, d := range *bom.Dependencies {
+		var dependsOn []string
+		if d.Dependencies != nil {
+			for _, ref := range *d.Dependencies {
+				dependsOn = append(dependsOn, normalize(ref))
+			}
+		}
+		normalized = append(normalized, cdx.Dependency{Ref: normalize(d.Ref), Dependencies: &dependsOn})
+	}
+
+	return normalized
+}
+
+/*
+mergeDependencies merges dependency graphs of multiple BOMs into one. Only references to the merged
+components are kept. Returns nil if none of the BOMs had a dependency graph.
+*/
+func mergeDependencies(dependencies []cdx.Dependency, components []cdx.Component) *[]cdx.Dependency {
+	knownRefs := make(map[string]bool)
+	for _, c := range components {
+		if c.BOMRef != "" {
+			knownRefs[c.BOMRef] = true
+		}
+	}
+
+	refsToDependencies := make(map[string]map[string]bool)
+	for _, d := range dependencies {
+		if !knownRefs[d.Ref] {
+			continue
+		}
+		if refsToDependencies[d.Ref] == nil {
+			refsToDependencies[d.Ref] = make(map[string]bool)
+		}
+		for _, ref := range *d.Dependencies {
+			if knownRefs[ref] {
+				refsToDependencies[d.Ref][ref] = true
+			}
+		}
+	}
+	if len(refsToDependencies) == 0 {
+		return nil
+	}
+
+	merged := make([]cdx.Dependency, 0, len(refsToDependencies))
+	for ref, dependsOn := range refsToDependencies {
+		refs := make([]string, 0, len(dependsOn))
+		for r := range dependsOn {
+			refs = append(refs, r)
+		}
+		sort.Strings(refs)
+		merged = append(merged, cdx.Dependency{Ref: ref, Dependencies: &refs})
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].Ref < merged[j].Ref })
+
+	return &merged
+}
+
 func MergeSBOMs(mergedSbomParam MergeSBOMParam) (*cdx.BOM, error) {
 	// Validate we are working with legit input
 	sboms := mergedSbomParam.SBOMs
@@ -243,7 +416,12 @@ func MergeSBOMs(mergedSbomParam MergeSBOMParam) (*cdx.BOM, error) {
 
 	// Gather components from every single cdx.BOM instance
 	var allComponents []*cdx.Component
+	var allDependencies []cdx.Dependency
+	var allProperties []cdx.Property
 	for _, b := range sboms {
+		if b.Properties != nil {
+			allProperties = mergeCollection[cdx.Property](*b.Properties, allProperties)
+		}
 		b = normalizeCPEs(normalizePackageNames(normalizePURLs(b)))
 		if b.Components != nil {
 			components := *b.Components
@@ -254,6 +432,7 @@ func MergeSBOMs(mergedSbomParam MergeSBOMParam) (*cdx.BOM, error) {
 				}
 			}
 		}
+		allDependencies = append(allDependencies, normalizeDependencyRefs(b)...)
 	}
 
 	/*
@@ -298,6 +477,11 @@ func MergeSBOMs(mergedSbomParam MergeSBOMParam) (*cdx.BOM, error) {
 	// Reconstruct final bom
 	bom := cdx.NewBOM()
 	bom.Components = &sortedComponents
+	bom.Dependencies = mergeDependencies(allDependencies, sortedComponents)
+	if len(allProperties) > 0 {
+		// BOM properties describe the whole project, e.g. language versions, so they're carried over as well
+		bom.Properties = &allProperties
+	}
 	bom.SerialNumber = uuid.New().URN()
 	bom.Metadata = &cdx.Metadata{
 		Timestamp: time.Now().Format(time.RFC3339),
@@ -89,4 +89,137 @@ func TestMergeBoms(t *testing.T) {
 		assert.Equal(t, *expectedBOM.Components, *got.Components)
 		assert.Equal(t, *expectedBOM.Metadata.Component, *got.Metadata.Component)
 	})
+	t.Run("merge dependency graphs correctly", func(t *testing.T) {
+		newBOM := func(components []cdx.Component, dependencies []cdx.Dependency) *cdx.BOM {
+			bom := cdx.NewBOM()
+			bom.Components = &components
+			bom.Dependencies = &dependencies
+			return bom
+		}
+		library := func(ref, purl string) cdx.Component {
+			return cdx.Component{BOMRef: ref, Type: cdx.ComponentTypeLibrary, Name: purl, PackageURL: purl}
+		}
+
+		firstBOM := newBOM(
+			[]cdx.Component{
+				library("app", "pkg:cargo/app@0.1.0"),
+				library("serde", "pkg:cargo/serde@1.0.136"),
+			},
+			[]cdx.Dependency{
+				{Ref: "app", Dependencies: &[]string{"serde", "unknown-ref"}},
+				{Ref: "metadata-component", Dependencies: &[]string{"app"}},
+			},
+		)
+		secondBOM := newBOM(
+			[]cdx.Component{
+				library("pkg:golang/github.com/spf13/cobra@v1.8.0", "pkg:golang/github.com/spf13/cobra@v1.8.0"),
+				library("pkg:golang/github.com/spf13/pflag@v1.0.5", "pkg:golang/github.com/spf13/pflag@v1.0.5"),
+				library("pkg:cargo/app@0.1.0", "pkg:cargo/app@0.1.0"),
+				library("pkg:cargo/log@0.4.20", "pkg:cargo/log@0.4.20"),
+			},
+			[]cdx.Dependency{
+				{
+					Ref:          "pkg:golang/github.com/spf13/cobra@v1.8.0",
+					Dependencies: &[]string{"pkg:golang/github.com/spf13/pflag@v1.0.5"},
+				},
+				{Ref: "pkg:cargo/app@0.1.0", Dependencies: &[]string{"pkg:cargo/log@0.4.20"}},
+			},
+		)
+
+		got, err := MergeSBOMs(MergeSBOMParam{SBOMs: []*cdx.BOM{firstBOM, secondBOM}})
+		require.NoError(t, err)
+
+		require.NotNil(t, got.Dependencies)
+		assert.Equal(t, []cdx.Dependency{
+			{Ref: "pkg:cargo/app@0.1.0", Dependencies: &[]string{"pkg:cargo/log@0.4.20", "pkg:cargo/serde@1.0.136"}},
+			{Ref: "pkg:golang/github.com/spf13/cobra@1.8.0", Dependencies: &[]string{"pkg:golang/github.com/spf13/pflag@1.0.5"}},
+		}, *got.Dependencies)
+	})
+	t.Run("merge PyPI components with differently spelled names", func(t *testing.T) {
+		firstBOM := cdx.NewBOM()
+		firstBOM.Components = &[]cdx.Component{{
+			Type:       cdx.ComponentTypeLibrary,
+			Name:       "typing_extensions",
+			PackageURL: "pkg:pypi/typing_extensions@4.7.1",
+		}}
+		secondBOM := cdx.NewBOM()
+		secondBOM.Components = &[]cdx.Component{{
+			Type:       cdx.ComponentTypeLibrary,
+			Name:       "typing-extensions",
+			PackageURL: "pkg:pypi/Typing.Extensions@4.7.1",
+		}}
+
+		got, err := MergeSBOMs(MergeSBOMParam{SBOMs: []*cdx.BOM{firstBOM, secondBOM}})
+		require.NoError(t, err)
+		require.Len(t, *got.Components, 1)
+		assert.Equal(t, "pkg:pypi/typing-extensions@4.7.1", (*got.Components)[0].PackageURL)
+	})
+	t.Run("carry BOM properties over", func(t *testing.T) {
+		firstBOM := cdx.NewBOM()
+		firstBOM.Properties = &[]cdx.Property{
+			{Name: "sbomsftw:golang:retract", Value: "v1.0.1"},
+			{Name: "sbomsftw:bundler:platform", Value: "x86_64-linux"},
+		}
+		secondBOM := cdx.NewBOM()
+		secondBOM.Properties = &[]cdx.Property{
+			{Name: "sbomsftw:bundler:platform", Value: "x86_64-linux"},
+			{Name: "sbomsftw:bundler:platform", Value: "arm64-darwin"},
+		}
+
+		got, err := MergeSBOMs(MergeSBOMParam{SBOMs: []*cdx.BOM{firstBOM, secondBOM, cdx.NewBOM()}})
+		require.NoError(t, err)
+		assert.Equal(t, &[]cdx.Property{
+			{Name: "sbomsftw:golang:retract", Value: "v1.0.1"},
+			{Name: "sbomsftw:bundler:platform", Value: "x86_64-linux"},
+			{Name: "sbomsftw:bundler:platform", Value: "arm64-darwin"},
+		}, got.Properties)
+
+		got, err = MergeSBOMs(MergeSBOMParam{SBOMs: []*cdx.BOM{cdx.NewBOM()}})
+		require.NoError(t, err)
+		assert.Nil(t, got.Properties)
+	})
+
+	t.Run("keep identifying PURL qualifiers & subpaths", func(t *testing.T) {
+		bom := cdx.NewBOM()
+		bom.Components = &[]cdx.Component{}
+		for _, purl := range []string{
+			"pkg:gem/nokogiri@1.13.10?platform=x86_64-linux",
+			"pkg:conda/numpy@1.24.3?build=py311h08b1b3b_0&channel=conda-forge&subdir=linux-64",
+			"pkg:maven/io.netty/netty-transport-native-epoll@4.1.94.Final?classifier=linux-x86_64&type=jar",
+			"pkg:maven/com.acme/bom@1.0.0?type=pom",
+			"pkg:maven/com.google.guava/guava@32.1.1-jre?type=jar",
+			"pkg:rpm/zlib@1.2.7-20.el7_9?arch=x86_64",
+			"pkg:helm/redis@17.11.3?repository_url=https%3A%2F%2Fcharts.bitnami.com%2Fbitnami",
+			"pkg:githubactions/acme/shared-workflows@main#.github/workflows/deploy.yml",
+		} {
+			*bom.Components = append(*bom.Components, cdx.Component{Type: cdx.ComponentTypeLibrary, Name: purl, PackageURL: purl})
+		}
+
+		var got []string
+		for _, c := range *normalizePURLs(bom).Components {
+			got = append(got, c.PackageURL)
+		}
+		assert.Equal(t, []string{
+			"pkg:gem/nokogiri@1.13.10?platform=x86_64-linux",
+			"pkg:conda/numpy@1.24.3?build=py311h08b1b3b_0&channel=conda-forge&subdir=linux-64",
+			"pkg:maven/io.netty/netty-transport-native-epoll@4.1.94.Final?classifier=linux-x86_64",
+			"pkg:maven/com.acme/bom@1.0.0?type=pom",
+			"pkg:maven/com.google.guava/guava@32.1.1-jre",
+			"pkg:rpm/zlib@1.2.7-20.el7_9",
+			"pkg:helm/redis@17.11.3?repository_url=https%3A%2F%2Fcharts.bitnami.com%2Fbitnami",
+			"pkg:githubactions/acme/shared-workflows@main#.github/workflows/deploy.yml",
+		}, got)
+	})
+}
+
+func TestNormalizePyPIName(t *testing.T) {
+	for name, want := range map[string]string{
+		"Django":             "django",
+		"typing_extensions":  "typing-extensions",
+		"zope.interface":     "zope-interface",
+		"Foo__Bar-._baz":     "foo-bar-baz",
+		"already-normalized": "already-normalized",
+	} {
+		assert.Equal(t, want, NormalizePyPIName(name))
+	}
 }
@@ -0,0 +1,91 @@
+package collectors
+
+import (
+	"context"
+	"errors"
+	"os"
+	fp "path/filepath"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	log "github.com/sirupsen/logrus"
+	"github.com/vinted/sbomsftw/pkg/bomtools"
+)
+
+// Lockfiles of Swift Package Manager, CocoaPods & Carthage, parsed natively without Xcode.
+var appleLockfileParsers = []struct {
+	filename string
+	parse    func([]byte) (*cdx.BOM, error)
+}{
+	{filename: "Package.resolved", parse: parsePackageResolved},
+	{filename: "Podfile.lock", parse: parsePodfileLock},
+	{filename: "Cartfile.resolved", parse: parseCartfileResolved},
+}
+
+var errNoAppleLockfiles = errors.New("no Package.resolved, Podfile.lock or Cartfile.resolved files could be parsed")
+
+// Apple collects dependencies of iOS & macOS projects.
+type Apple struct{}
+
+func NewAppleCollector() Apple {
+	return Apple{}
+}
+
+// MatchLanguageFiles implements LanguageCollector interface.
+func (a Apple) MatchLanguageFiles(isDir bool, filepath string) bool {
+	if isDir {
+		return false
+	}
+	for _, d := range strings.Split(fp.Dir(filepath), string(os.PathSeparator)) {
+		if d == "Pods" || d == "Carthage" || d == ".build" { // Ignore checked out dependencies
+			return false
+		}
+	}
+
+	for _, p := range appleLockfileParsers {
+		if fp.Base(filepath) == p.filename {
+			return true
+		}
+	}
+
+	return false
+}
+
+// BootstrapLanguageFiles implements LanguageCollector interface.
+func (a Apple) BootstrapLanguageFiles(_ context.Context, bomRoots []string) []string {
+	return SquashToDirs(bomRoots)
+}
+
+/*
+GenerateBOM implements LanguageCollector interface. Every lockfile in the bom root is parsed natively
+& the resulting BOMs are merged into one, so neither Xcode nor macOS is needed.
+*/
+func (a Apple) GenerateBOM(_ context.Context, bomRoot string) (*cdx.BOM, error) {
+	var boms []*cdx.BOM
+	for _, p := range appleLockfileParsers {
+		contents, err := os.ReadFile(fp.Join(bomRoot, p.filename))
+		if err != nil {
+			continue
+		}
+		bom, err := p.parse(contents)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"collector":       a,
+				"collection path": bomRoot,
+				"error":           err,
+			}).Debugf("can't parse %s", p.filename)
+			continue
+		}
+		boms = append(boms, bom)
+	}
+	if len(boms) == 0 {
+		return nil, errNoAppleLockfiles
+	}
+
+	return bomtools.MergeSBOMs(bomtools.MergeSBOMParam{SBOMs: boms})
+}
+
+// String implements LanguageCollector interface.
+func (a Apple) String() string {
+	return "apple collector"
+}
@@ -0,0 +1,94 @@
+package collectors
+
+import (
+	"context"
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppleCollector(t *testing.T) {
+	t.Run("match correct package files", func(t *testing.T) {
+		appleCollector := Apple{}
+		for _, f := range []string{
+			"Package.resolved",
+			"/opt/App.xcodeproj/project.xcworkspace/xcshareddata/swiftpm/Package.resolved",
+			"/opt/Podfile.lock",
+			"/opt/Cartfile.resolved",
+		} {
+			assert.True(t, appleCollector.MatchLanguageFiles(false, f))
+		}
+		assert.False(t, appleCollector.MatchLanguageFiles(false, "/opt/Pods/Manifest.lock"))
+		assert.False(t, appleCollector.MatchLanguageFiles(false, "/opt/Carthage/Checkouts/RxSwift/Cartfile.resolved"))
+		assert.False(t, appleCollector.MatchLanguageFiles(false, "/opt/Podfile"))
+		assert.False(t, appleCollector.MatchLanguageFiles(true, "Podfile.lock"))
+	})
+
+	t.Run("bootstrap language files correctly", func(t *testing.T) {
+		got := Apple{}.BootstrapLanguageFiles(context.Background(), []string{
+			"/tmp/some-random-dir/Podfile.lock",
+			"/tmp/some-random-dir/Cartfile.resolved",
+			"/tmp/some-random-dir/inner-dir/Package.resolved",
+		})
+		assert.ElementsMatch(t, []string{"/tmp/some-random-dir", "/tmp/some-random-dir/inner-dir"}, got)
+	})
+
+	t.Run("generate BOM natively from every lockfile", func(t *testing.T) {
+		got, err := Apple{}.GenerateBOM(context.Background(), "../../integration/test/apple/app")
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []string{
+			"pkg:cocoapods/Firebase@10.9.0",
+			"pkg:cocoapods/FirebaseAnalytics@10.9.0",
+			"pkg:cocoapods/Kingfisher@7.6.2",
+			"pkg:cocoapods/SnapKit@5.6.0",
+			"pkg:generic/GoogleMaps@7.4.0",
+			"pkg:swift/github.com/Alamofire/Alamofire@5.6.4",
+			"pkg:swift/github.com/ReactiveX/RxSwift@6.5.0",
+			"pkg:swift/github.com/apple/swift-collections@937e904258d22af6e447a0b72c0bc67583ef64a2",
+			"pkg:swift/github.com/onevcat/Kingfisher@7.6.2",
+			"pkg:swift/mona/linkedlist@1.1.0",
+		}, purls(t, got))
+
+		components := componentsByPURL(t, got)
+		assert.Equal(t, &[]cdx.ExternalReference{{
+			Type: cdx.ERTypeVCS,
+			URL:  "https://github.com/onevcat/Kingfisher.git#3ec0ab0bca4feb56e8b33e289c9496e89059dd08",
+		}}, components["pkg:cocoapods/Kingfisher@7.6.2"].ExternalReferences)
+		assert.Equal(t, &[]cdx.ExternalReference{
+			{Type: cdx.ERTypeDistribution, URL: "https://dl.google.com/geosdk/GoogleMaps.json"},
+		}, components["pkg:generic/GoogleMaps@7.4.0"].ExternalReferences)
+	})
+
+	t.Run("parse Package.resolved v1 correctly", func(t *testing.T) {
+		got, err := Apple{}.GenerateBOM(context.Background(), "../../integration/test/apple/legacy-spm")
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"pkg:swift/github.com/SwiftyJSON/SwiftyJSON@5.0.1"}, purls(t, got))
+		assert.Equal(t, "SwiftyJSON", componentsByPURL(t, got)["pkg:swift/github.com/SwiftyJSON/SwiftyJSON@5.0.1"].Name)
+	})
+
+	t.Run("format Swift Package URLs correctly", func(t *testing.T) {
+		for repositoryURL, want := range map[string]string{
+			"https://github.com/Alamofire/Alamofire.git":      "pkg:swift/github.com/Alamofire/Alamofire@5.6.4",
+			"git@github.com:Alamofire/Alamofire.git":          "pkg:swift/github.com/Alamofire/Alamofire@5.6.4",
+			"ssh://git@gitlab.example.com/ios/Alamofire.git/": "pkg:swift/gitlab.example.com/ios/Alamofire@5.6.4",
+		} {
+			assert.Equal(t, want, swiftPURL(repositoryURL, "5.6.4"))
+		}
+	})
+
+	t.Run("return an error when no lockfiles can be parsed", func(t *testing.T) {
+		_, err := Apple{}.GenerateBOM(context.Background(), "/tmp/some-random-dir")
+		assert.ErrorIs(t, err, errNoAppleLockfiles)
+
+		_, err = parseCartfileResolved([]byte(`github "Alamofire/Alamofire"`))
+		assert.Error(t, err)
+	})
+
+	t.Run("implement Stringer correctly", func(t *testing.T) {
+		assert.Equal(t, "apple collector", Apple{}.String())
+	})
+}
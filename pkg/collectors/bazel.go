@@ -0,0 +1,399 @@
+package collectors
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+const bazelCentralRegistry = "https://bcr.bazel.build"
+
+var (
+	// Repository rules of WORKSPACE files, optionally wrapped in maybe. E.g. maybe(http_archive, name = ...)
+	bazelRepositoryRulePattern = regexp.MustCompile(
+		`(?m)^[ \t]*(?:maybe\s*\(\s*)?(http_archive|http_file|http_jar|git_repository|new_git_repository)\s*([(,])`,
+	)
+	// Versions embedded into archive names & paths. E.g. rules_go-v0.41.0.zip
+	archiveVersionPattern = regexp.MustCompile(`^(?:[0-9a-f]{40}|v?(\d+(?:\.\d+)+))$|[-_]v?(\d+(?:\.\d+)+)`)
+	archiveExtensions     = []string{".tar.gz", ".tgz", ".tar.xz", ".tar.bz2", ".tar.zst", ".tar", ".zip", ".jar"}
+)
+
+/*
+bazelModuleLockfile represents MODULE.bazel.lock files. Lockfiles of Bazel 7.0 & 7.1 store the resolved module
+graph, while newer lockfiles store hashes of registry files only - source.json files of selected modules included.
+*/
+type bazelModuleLockfile struct {
+	LockFileVersion    int                                   `json:"lockFileVersion"`
+	RegistryFileHashes map[string]*string                    `json:"registryFileHashes"`
+	ModuleDepGraph     map[string]bazelModule                `json:"moduleDepGraph"`
+	ModuleExtensions   map[string]map[string]json.RawMessage `json:"moduleExtensions"`
+}
+
+type bazelModule struct {
+	Name     string        `json:"name"`
+	Version  string        `json:"version"`
+	RepoSpec bazelRepoSpec `json:"repoSpec"`
+}
+
+// bazelRepoSpec is a repository rule invocation, e.g. http_archive, along with its attributes.
+type bazelRepoSpec struct {
+	RuleClassName string `json:"ruleClassName"`
+	RepoRuleID    string `json:"repoRuleId"` // Bazel 8 & newer. E.g. @@bazel_tools//tools/build_defs/repo:http.bzl%http_archive
+	Attributes    struct {
+		Name      string   `json:"name"`
+		URL       string   `json:"url"`
+		URLs      []string `json:"urls"`
+		SHA256    string   `json:"sha256"`
+		Integrity string   `json:"integrity"`
+		Remote    string   `json:"remote"`
+		Commit    string   `json:"commit"`
+		Tag       string   `json:"tag"`
+	} `json:"attributes"`
+}
+
+func (s bazelRepoSpec) rule() string {
+	if s.RuleClassName != "" {
+		return s.RuleClassName
+	}
+	_, rule, _ := strings.Cut(s.RepoRuleID, "%")
+
+	return rule
+}
+
+/*
+archiveVersion guesses the version of an archive from its download URL. Archive names are inspected first,
+then the rest of the path. Commit hashes & version numbers are recognized. E.g. given the following input:
+
+	https://github.com/bazelbuild/rules_go/releases/download/v0.41.0/rules_go-v0.41.0.zip
+
+this function will return: 0.41.0
+*/
+func archiveVersion(archiveURL string) string {
+	parsed, err := url.Parse(archiveURL)
+	if err != nil {
+		return ""
+	}
+
+	segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	for i := len(segments) - 1; i >= 0; i-- {
+		segment := segments[i]
+		for _, e := range archiveExtensions {
+			if trimmed, ok := strings.CutSuffix(segment, e); ok {
+				segment = trimmed
+				break
+			}
+		}
+		if matches := archiveVersionPattern.FindStringSubmatch(segment); matches != nil {
+			switch {
+			case matches[1] != "":
+				return matches[1]
+			case matches[2] != "":
+				return matches[2]
+			default:
+				return matches[0]
+			}
+		}
+	}
+
+	return ""
+}
+
+/*
+bazelRepoComponent converts a repository rule into a component. Archives & files downloaded from GitHub
+become pkg:github components, other downloads become pkg:generic components named after the repository.
+Repositories cloned with git are converted the same way as git submodules. false is returned for other rules,
+e.g. local_repository, & for rules without any URLs.
+*/
+func bazelRepoComponent(name string, spec bazelRepoSpec) (cdx.Component, bool) {
+	attributes := spec.Attributes
+	if attributes.Name != "" {
+		name = attributes.Name
+	}
+
+	var component cdx.Component
+	switch spec.rule() {
+	case "http_archive", "http_file", "http_jar":
+		urls := attributes.URLs
+		if attributes.URL != "" {
+			urls = append([]string{attributes.URL}, urls...)
+		}
+		if len(urls) == 0 {
+			return cdx.Component{}, false
+		}
+
+		version := archiveVersion(urls[0])
+		component = newLibraryComponent(packageURL("generic", "", name, version), name, version)
+		if host, repositoryPath, ok := gitRemoteAddress(urls[0]); ok && host == "github.com" {
+			if segments := strings.Split(repositoryPath, "/"); len(segments) > 2 {
+				component = newLibraryComponent(packageURL("github", segments[0], segments[1], version), segments[1], version)
+				component.Group = segments[0]
+			}
+		}
+		component.Scope = cdx.ScopeRequired
+		if attributes.SHA256 != "" {
+			addHashes(&component, cdx.Hash{Algorithm: cdx.HashAlgoSHA256, Value: attributes.SHA256})
+		}
+		addHashes(&component, hashesFromSRI(attributes.Integrity)...)
+		addExternalReference(&component, cdx.ERTypeDistribution, urls[0])
+	case "git_repository", "new_git_repository":
+		commit := attributes.Commit
+		if commit == "" {
+			commit = attributes.Tag
+		}
+		component = gitComponent(attributes.Remote, name, commit)
+	default:
+		return cdx.Component{}, false
+	}
+	addProperty(&component, "bazel:repository", name)
+
+	return component, true
+}
+
+/*
+bazelRegistryModules converts hashes of registry files into pkg:bazel components. source.json files are
+fetched for selected module versions only, so those identify the resolved module graph. E.g.
+
+	https://bcr.bazel.build/modules/rules_cc/0.0.9/source.json
+*/
+func bazelRegistryModules(registryFileHashes map[string]*string) []cdx.Component {
+	var components []cdx.Component
+	for _, registryFile := range sortedKeys(registryFileHashes) {
+		registry, modulePath, ok := strings.Cut(registryFile, "/modules/")
+		if !ok || path.Base(modulePath) != "source.json" {
+			continue
+		}
+		segments := strings.Split(modulePath, "/")
+		if len(segments) != 3 {
+			continue
+		}
+		name, version := segments[0], segments[1]
+
+		component := newLibraryComponent(packageURL("bazel", "", name, version), name, version)
+		component.Scope = cdx.ScopeRequired
+		if hash := registryFileHashes[registryFile]; hash != nil {
+			addProperty(&component, "bazel:sourceJsonSha256", *hash)
+		}
+		if registry != bazelCentralRegistry {
+			addExternalReference(&component, cdx.ERTypeDistribution, registryFile)
+		}
+		components = append(components, component)
+	}
+
+	return components
+}
+
+/*
+bazelExtensionRepoSpecs returns repositories generated by module extensions. Lockfiles of Bazel 7.0 store
+generated repositories directly under extensions, newer lockfiles nest them under extension variants.
+E.g. general or os:linux,arch:amd64
+*/
+func bazelExtensionRepoSpecs(extensions map[string]map[string]json.RawMessage) map[string]bazelRepoSpec {
+	repoSpecs := make(map[string]bazelRepoSpec)
+	for _, extension := range extensions {
+		for key, value := range extension {
+			var specs map[string]bazelRepoSpec
+			if key == "generatedRepoSpecs" {
+				_ = json.Unmarshal(value, &specs)
+			} else {
+				var variant struct {
+					GeneratedRepoSpecs map[string]bazelRepoSpec `json:"generatedRepoSpecs"`
+				}
+				_ = json.Unmarshal(value, &variant)
+				specs = variant.GeneratedRepoSpecs
+			}
+			for name, spec := range specs {
+				repoSpecs[name] = spec
+			}
+		}
+	}
+
+	return repoSpecs
+}
+
+/*
+parseModuleBazelLock converts MODULE.bazel.lock contents into components. Bazel modules become pkg:bazel
+components, while repositories generated by module extensions, e.g. http_archive rules, are converted with
+bazelRepoComponent. The root module & modules overridden with local paths are skipped.
+*/
+func parseModuleBazelLock(contents []byte) (*cdx.BOM, error) {
+	var lockfile bazelModuleLockfile
+	if err := json.Unmarshal(contents, &lockfile); err != nil {
+		return nil, fmt.Errorf("can't parse MODULE.bazel.lock: %w", err)
+	}
+	if lockfile.LockFileVersion == 0 {
+		return nil, errors.New("can't parse MODULE.bazel.lock: lockFileVersion is missing")
+	}
+
+	components := bazelRegistryModules(lockfile.RegistryFileHashes)
+	for _, key := range sortedKeys(lockfile.ModuleDepGraph) {
+		module := lockfile.ModuleDepGraph[key]
+		if key == "<root>" || module.Name == "" || module.Version == "" {
+			continue
+		}
+		if module.RepoSpec.rule() == "local_repository" {
+			continue
+		}
+
+		component := newLibraryComponent(packageURL("bazel", "", module.Name, module.Version), module.Name, module.Version)
+		component.Scope = cdx.ScopeRequired
+		addHashes(&component, hashesFromSRI(module.RepoSpec.Attributes.Integrity)...)
+		if len(module.RepoSpec.Attributes.URLs) > 0 {
+			addExternalReference(&component, cdx.ERTypeDistribution, module.RepoSpec.Attributes.URLs[0])
+		}
+		components = append(components, component)
+	}
+
+	repoSpecs := bazelExtensionRepoSpecs(lockfile.ModuleExtensions)
+	for _, name := range sortedKeys(repoSpecs) {
+		if c, ok := bazelRepoComponent(name, repoSpecs[name]); ok {
+			components = append(components, c)
+		}
+	}
+
+	return bomFromComponents(uniqueComponents(components)), nil
+}
+
+/*
+starlarkString unquotes a Starlark string literal. false is returned for any other expression,
+e.g. string concatenations or format calls.
+*/
+func starlarkString(expression string) (string, bool) {
+	expression = strings.TrimSpace(expression)
+	if len(expression) < 2 || strings.HasPrefix(expression, `"""`) || strings.HasPrefix(expression, "'''") {
+		return "", false
+	}
+
+	switch quote := expression[0]; {
+	case quote == '"':
+		s, err := strconv.Unquote(expression)
+		return s, err == nil
+	case quote == '\'' && expression[len(expression)-1] == '\'':
+		s := expression[1 : len(expression)-1]
+		return s, !strings.ContainsRune(s, '\'')
+	}
+
+	return "", false
+}
+
+/*
+starlarkCallArguments collects keyword arguments of a Starlark function call, which start at the beginning of
+source & end with the closing parenthesis of the call. Only arguments with string or string list values are
+collected, list elements that aren't string literals are skipped. E.g. given the following input:
+
+	name = "zlib", urls = ["https://zlib.net/zlib-1.3.tar.gz"], build_file = "//third_party:zlib.BUILD")
+
+this function will return: {"name": ["zlib"], "urls": ["https://zlib.net/zlib-1.3.tar.gz"], ...}
+*/
+func starlarkCallArguments(call string) map[string][]string {
+	source := []byte(call)
+	arguments := make(map[string][]string)
+	collect := func(argument string) {
+		key, value, ok := strings.Cut(argument, "=")
+		if !ok {
+			return
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if s, ok := starlarkString(value); ok {
+			arguments[key] = []string{s}
+			return
+		}
+		if !strings.HasPrefix(value, "[") || !strings.HasSuffix(value, "]") {
+			return
+		}
+		values := []string{}
+		for _, element := range strings.Split(value[1:len(value)-1], ",") {
+			if s, ok := starlarkString(element); ok {
+				values = append(values, s)
+			}
+		}
+		arguments[key] = values
+	}
+
+	depth, start := 0, 0
+	for i := 0; i < len(source); i++ {
+		switch c := source[i]; c {
+		case '"', '\'':
+			quote := []byte{c}
+			if tripleQuote := bytes.Repeat(quote, 3); bytes.HasPrefix(source[i:], tripleQuote) {
+				quote = tripleQuote
+			}
+			// Skip to the closing quote, escaped characters included
+			for j := i + len(quote); j < len(source); j++ {
+				if source[j] == '\\' {
+					j++
+					continue
+				}
+				if bytes.HasPrefix(source[j:], quote) {
+					i = j + len(quote) - 1
+					break
+				}
+			}
+		case '#': // Comments are blanked out, as they can contain anything
+			for ; i < len(source) && source[i] != '\n'; i++ {
+				source[i] = ' '
+			}
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			if depth == 0 {
+				collect(string(source[start:i]))
+				return arguments
+			}
+			depth--
+		case ',':
+			if depth == 0 {
+				collect(string(source[start:i]))
+				start = i + 1
+			}
+		}
+	}
+
+	return arguments
+}
+
+/*
+parseBazelWorkspace converts repository rules of WORKSPACE files into components with bazelRepoComponent.
+Only rules with literal attribute values can be resolved, while rules invoked by macros loaded from other
+files are out of reach.
+*/
+func parseBazelWorkspace(contents []byte) (*cdx.BOM, error) {
+	source := string(contents)
+	matches := bazelRepositoryRulePattern.FindAllStringSubmatchIndex(source, -1)
+	if len(matches) == 0 {
+		return nil, errors.New("can't parse WORKSPACE: no repository rules found")
+	}
+
+	components := make([]cdx.Component, 0, len(matches))
+	for _, m := range matches {
+		arguments := starlarkCallArguments(source[m[1]:])
+		first := func(key string) string {
+			if values := arguments[key]; len(values) > 0 {
+				return values[0]
+			}
+			return ""
+		}
+
+		var spec bazelRepoSpec
+		spec.RuleClassName = source[m[2]:m[3]]
+		spec.Attributes.URL = first("url")
+		spec.Attributes.URLs = arguments["urls"]
+		spec.Attributes.SHA256 = first("sha256")
+		spec.Attributes.Integrity = first("integrity")
+		spec.Attributes.Remote = first("remote")
+		spec.Attributes.Commit = first("commit")
+		spec.Attributes.Tag = first("tag")
+		if c, ok := bazelRepoComponent(first("name"), spec); ok {
+			components = append(components, c)
+		}
+	}
+
+	return bomFromComponents(uniqueComponents(components)), nil
+}
@@ -0,0 +1,90 @@
+package collectors
+
+import (
+	"context"
+	"errors"
+	"os"
+	fp "path/filepath"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	log "github.com/sirupsen/logrus"
+	"github.com/vinted/sbomsftw/pkg/bomtools"
+)
+
+// Lockfiles of Mix & Rebar3, parsed natively without Elixir or Erlang.
+var beamLockfileParsers = []struct {
+	filename string
+	parse    func([]byte) (*cdx.BOM, error)
+}{
+	{filename: "mix.lock", parse: parseMixLockfile},
+	{filename: "rebar.lock", parse: parseRebarLockfile},
+}
+
+var errNoBEAMLockfiles = errors.New("no mix.lock or rebar.lock files could be parsed")
+
+// BEAM collects dependencies of Elixir & Erlang projects.
+type BEAM struct{}
+
+func NewBEAMCollector() BEAM {
+	return BEAM{}
+}
+
+// MatchLanguageFiles implements LanguageCollector interface.
+func (b BEAM) MatchLanguageFiles(isDir bool, filepath string) bool {
+	if isDir {
+		return false
+	}
+	for _, d := range strings.Split(fp.Dir(filepath), string(os.PathSeparator)) {
+		if d == "deps" || d == "_build" { // Ignore fetched dependencies & build artifacts
+			return false
+		}
+	}
+
+	for _, p := range beamLockfileParsers {
+		if fp.Base(filepath) == p.filename {
+			return true
+		}
+	}
+
+	return false
+}
+
+// BootstrapLanguageFiles implements LanguageCollector interface.
+func (b BEAM) BootstrapLanguageFiles(_ context.Context, bomRoots []string) []string {
+	return SquashToDirs(bomRoots)
+}
+
+/*
+GenerateBOM implements LanguageCollector interface. Every lockfile in the bom root is parsed natively
+& the resulting BOMs are merged into one, so neither Elixir nor Erlang is needed.
+*/
+func (b BEAM) GenerateBOM(_ context.Context, bomRoot string) (*cdx.BOM, error) {
+	var boms []*cdx.BOM
+	for _, p := range beamLockfileParsers {
+		contents, err := os.ReadFile(fp.Join(bomRoot, p.filename))
+		if err != nil {
+			continue
+		}
+		bom, err := p.parse(contents)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"collector":       b,
+				"collection path": bomRoot,
+				"error":           err,
+			}).Debugf("can't parse %s", p.filename)
+			continue
+		}
+		boms = append(boms, bom)
+	}
+	if len(boms) == 0 {
+		return nil, errNoBEAMLockfiles
+	}
+
+	return bomtools.MergeSBOMs(bomtools.MergeSBOMParam{SBOMs: boms})
+}
+
+// String implements LanguageCollector interface.
+func (b BEAM) String() string {
+	return "beam collector"
+}
@@ -0,0 +1,105 @@
+package collectors
+
+import (
+	"context"
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBEAMCollector(t *testing.T) {
+	t.Run("match correct package files", func(t *testing.T) {
+		beamCollector := BEAM{}
+		assert.True(t, beamCollector.MatchLanguageFiles(false, "mix.lock"))
+		assert.True(t, beamCollector.MatchLanguageFiles(false, "/opt/apps/api/rebar.lock"))
+		assert.False(t, beamCollector.MatchLanguageFiles(false, "/opt/deps/cowboy/rebar.lock"))
+		assert.False(t, beamCollector.MatchLanguageFiles(false, "/opt/_build/default/lib/jsx/rebar.lock"))
+		assert.False(t, beamCollector.MatchLanguageFiles(false, "/opt/mix.exs"))
+		assert.False(t, beamCollector.MatchLanguageFiles(true, "mix.lock"))
+	})
+
+	t.Run("bootstrap language files correctly", func(t *testing.T) {
+		got := BEAM{}.BootstrapLanguageFiles(context.Background(), []string{
+			"/tmp/some-random-dir/mix.lock",
+			"/tmp/some-random-dir/inner-dir/rebar.lock",
+		})
+		assert.ElementsMatch(t, []string{"/tmp/some-random-dir", "/tmp/some-random-dir/inner-dir"}, got)
+	})
+
+	t.Run("generate BOM natively from mix.lock", func(t *testing.T) {
+		got, err := BEAM{}.GenerateBOM(context.Background(), "../../integration/test/beam/elixir")
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []string{
+			"pkg:hex/acme/acme_auth@0.3.2",
+			"pkg:hex/cowboy@2.10.0",
+			"pkg:hex/cowlib@2.12.1",
+			"pkg:hex/jason@1.4.1",
+			"pkg:hex/phoenix@8d3ba0a1e3b4c5d6e7f8091a2b3c4d5e6f708192",
+			"pkg:hex/ranch@1.8.0",
+		}, purls(t, got))
+
+		components := componentsByPURL(t, got)
+		assert.Equal(t, &[]cdx.Hash{
+			{Algorithm: cdx.HashAlgoSHA256, Value: "ff9ffeff91dae4ae270dd975642997afe2a1179d94b1887863e43f681a203e26"},
+			{Algorithm: cdx.HashAlgoSHA256, Value: "3afdccb7183cc6f143cb14d3cf51fa00e53db9ec80cdcd525482f5e99bc41d6b"},
+		}, components["pkg:hex/cowboy@2.10.0"].Hashes)
+		assert.Equal(t, &[]cdx.ExternalReference{{
+			Type: cdx.ERTypeVCS,
+			URL:  "https://github.com/phoenixframework/phoenix.git#8d3ba0a1e3b4c5d6e7f8091a2b3c4d5e6f708192",
+		}}, components["pkg:hex/phoenix@8d3ba0a1e3b4c5d6e7f8091a2b3c4d5e6f708192"].ExternalReferences)
+
+		require.NotNil(t, got.Dependencies)
+		dependencies := make(map[string][]string)
+		for _, d := range *got.Dependencies {
+			dependencies[d.Ref] = *d.Dependencies
+		}
+		assert.Equal(t, []string{"pkg:hex/cowlib@2.12.1", "pkg:hex/ranch@1.8.0"}, dependencies["pkg:hex/cowboy@2.10.0"])
+		assert.Equal(t, []string{"pkg:hex/jason@1.4.1"}, dependencies["pkg:hex/acme/acme_auth@0.3.2"])
+		assert.Empty(t, dependencies["pkg:hex/jason@1.4.1"])
+	})
+
+	t.Run("generate BOM natively from rebar.lock", func(t *testing.T) {
+		got, err := BEAM{}.GenerateBOM(context.Background(), "../../integration/test/beam/erlang")
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []string{
+			"pkg:hex/certifi@2.9.0",
+			"pkg:hex/hackney@1.18.1",
+			"pkg:hex/jsx@bc6b44d0b6d8c7d0bd6e5a1e2f3a4b5c6d7e8f90",
+		}, purls(t, got))
+
+		certifi := componentsByPURL(t, got)["pkg:hex/certifi@2.9.0"]
+		assert.Equal(t, &[]cdx.Hash{
+			{Algorithm: cdx.HashAlgoSHA256, Value: "6f2a475689dd47f19fb74334859d460a2dc4e3252a3324bd2111b8f0429e7e21"},
+			{Algorithm: cdx.HashAlgoSHA256, Value: "266da46bdb06d6c5d35fde799bcb28d36d985d424ad7c08b5bb48f5b5cdd4641"},
+		}, certifi.Hashes)
+		assert.Equal(t, &[]cdx.Property{{Name: "sbomsftw:rebar:indirect", Value: "true"}}, certifi.Properties)
+	})
+
+	t.Run("parse legacy rebar.lock correctly", func(t *testing.T) {
+		got, err := parseRebarLockfile([]byte(`[{<<"jsx">>,{pkg,<<"jsx">>,<<"2.8.0">>},0}].`))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pkg:hex/jsx@2.8.0"}, purls(t, got))
+	})
+
+	t.Run("return an error for malformed lockfiles", func(t *testing.T) {
+		_, err := parseMixLockfile([]byte(`%{"cowboy": {:hex, :cowboy, "2.10.0"`))
+		assert.Error(t, err)
+		_, err = parseMixLockfile([]byte(`[cowboy: "2.10.0"]`))
+		assert.Error(t, err)
+		_, err = parseRebarLockfile([]byte(`{"1.2.0", <<"jsx">>}.`))
+		assert.Error(t, err)
+	})
+
+	t.Run("return an error when no lockfiles can be parsed", func(t *testing.T) {
+		_, err := BEAM{}.GenerateBOM(context.Background(), "/tmp/some-random-dir")
+		assert.ErrorIs(t, err, errNoBEAMLockfiles)
+	})
+
+	t.Run("implement Stringer correctly", func(t *testing.T) {
+		assert.Equal(t, "beam collector", BEAM{}.String())
+	})
+}
@@ -0,0 +1,298 @@
+package collectors
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+/*
+Erlang & Elixir terms as found in mix.lock & rebar.lock files. Strings, charlists & binaries are decoded
+as Go strings, lists as []interface{} & maps as map[string]interface{}. Keyword list entries, such as
+hex: :cowlib, are decoded as two element tuples - just like the BEAM does.
+*/
+type (
+	beamAtom  string
+	beamTuple []interface{}
+)
+
+// beamTermParser is a minimal recursive descent parser of literal Erlang & Elixir terms.
+type beamTermParser struct {
+	input []byte
+	pos   int
+}
+
+// parseBEAMTerms parses every term in the input. Erlang terms are terminated by a dot, Elixir terms aren't.
+func parseBEAMTerms(contents []byte) ([]interface{}, error) {
+	p := &beamTermParser{input: contents}
+
+	var terms []interface{}
+	for {
+		p.skipWhitespace()
+		if p.eof() {
+			return terms, nil
+		}
+		term, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, term)
+
+		p.skipWhitespace()
+		p.consume(".")
+	}
+}
+
+func (p *beamTermParser) eof() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *beamTermParser) peek(prefix string) bool {
+	return strings.HasPrefix(string(p.input[p.pos:]), prefix)
+}
+
+func (p *beamTermParser) consume(prefix string) bool {
+	if !p.peek(prefix) {
+		return false
+	}
+	p.pos += len(prefix)
+
+	return true
+}
+
+func (p *beamTermParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("offset %d: %s", p.pos, fmt.Sprintf(format, args...))
+}
+
+// skipWhitespace skips whitespace, Erlang (%) & Elixir (#) comments.
+func (p *beamTermParser) skipWhitespace() {
+	for !p.eof() {
+		switch c := p.input[p.pos]; {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			p.pos++
+		case c == '#' || (c == '%' && !p.peek("%{")):
+			for !p.eof() && p.input[p.pos] != '\n' {
+				p.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (p *beamTermParser) term() (interface{}, error) {
+	p.skipWhitespace()
+	if p.eof() {
+		return nil, p.errorf("unexpected end of input")
+	}
+
+	switch c := p.input[p.pos]; {
+	case p.consume("%{"):
+		return p.mapping()
+	case p.consume("{"):
+		elements, err := p.sequence("}")
+		return beamTuple(elements), err
+	case p.consume("["):
+		return p.sequence("]")
+	case p.consume("<<"):
+		return p.binary()
+	case c == '"':
+		return p.quoted('"')
+	case c == '\'':
+		atom, err := p.quoted('\'')
+		return beamAtom(atom), err
+	case p.consume(":"): // Elixir atoms. E.g. :hex or :"hex"
+		if !p.eof() && p.input[p.pos] == '"' {
+			atom, err := p.quoted('"')
+			return beamAtom(atom), err
+		}
+		return beamAtom(p.identifier()), nil
+	case c == '-' || (c >= '0' && c <= '9'):
+		return p.integer()
+	default:
+		if identifier := p.identifier(); identifier != "" {
+			return beamAtom(identifier), nil
+		}
+		return nil, p.errorf("unexpected %q", c)
+	}
+}
+
+/*
+element parses a list, tuple or map element. Keyword syntax is expanded into two element tuples,
+e.g. both optional: false & "optional": false are decoded as {:optional, false}
+*/
+func (p *beamTermParser) element() (interface{}, error) {
+	term, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+
+	var key string
+	switch t := term.(type) {
+	case beamAtom:
+		key = string(t)
+	case string:
+		key = t
+	default:
+		return term, nil
+	}
+	if !p.peek(": ") && !p.peek(":\n") && !p.peek(":\t") {
+		return term, nil
+	}
+	p.pos++
+
+	value, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+
+	return beamTuple{beamAtom(key), value}, nil
+}
+
+// sequence parses comma separated elements up to the closing delimiter. Trailing commas are allowed.
+func (p *beamTermParser) sequence(closing string) ([]interface{}, error) {
+	elements := []interface{}{}
+	for {
+		p.skipWhitespace()
+		if p.consume(closing) {
+			return elements, nil
+		}
+		element, err := p.element()
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, element)
+
+		p.skipWhitespace()
+		if !p.consume(",") && !p.peek(closing) {
+			return nil, p.errorf("expected , or %s", closing)
+		}
+	}
+}
+
+// mapping parses Elixir maps. Keys are either keywords or arbitrary terms followed by =>
+func (p *beamTermParser) mapping() (map[string]interface{}, error) {
+	m := make(map[string]interface{})
+	for {
+		p.skipWhitespace()
+		if p.consume("}") {
+			return m, nil
+		}
+		key, err := p.element()
+		if err != nil {
+			return nil, err
+		}
+
+		var value interface{}
+		p.skipWhitespace()
+		if p.consume("=>") {
+			if value, err = p.term(); err != nil {
+				return nil, err
+			}
+		} else if keyword, ok := key.(beamTuple); ok && len(keyword) == 2 {
+			key, value = keyword[0], keyword[1]
+		} else {
+			return nil, p.errorf("expected =>")
+		}
+		m[fmt.Sprint(key)] = value
+
+		p.skipWhitespace()
+		if !p.consume(",") && !p.peek("}") {
+			return nil, p.errorf("expected , or }")
+		}
+	}
+}
+
+// binary parses Erlang binaries holding a single string, e.g. <<"cowboy">>
+func (p *beamTermParser) binary() (string, error) {
+	p.skipWhitespace()
+	if p.consume(">>") {
+		return "", nil
+	}
+	s, err := p.quoted('"')
+	if err != nil {
+		return "", err
+	}
+	p.skipWhitespace()
+	if !p.consume(">>") {
+		return "", p.errorf("expected >>")
+	}
+
+	return s, nil
+}
+
+// quoted parses strings & quoted atoms delimited by the quote given.
+func (p *beamTermParser) quoted(quote byte) (string, error) {
+	p.pos++ // Opening quote
+
+	var b strings.Builder
+	for !p.eof() {
+		c := p.input[p.pos]
+		p.pos++
+		switch {
+		case c == quote:
+			return b.String(), nil
+		case c == '\\' && !p.eof():
+			b.WriteByte(p.input[p.pos])
+			p.pos++
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return "", p.errorf("unterminated string")
+}
+
+func (p *beamTermParser) identifier() string {
+	start := p.pos
+	for !p.eof() {
+		c := p.input[p.pos]
+		if !(c == '_' || c == '@' || c == '?' || c == '!' ||
+			(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9' && p.pos > start)) {
+			break
+		}
+		p.pos++
+	}
+
+	return string(p.input[start:p.pos])
+}
+
+func (p *beamTermParser) integer() (int, error) {
+	start := p.pos
+	p.consume("-")
+	for !p.eof() && p.input[p.pos] >= '0' && p.input[p.pos] <= '9' {
+		p.pos++
+	}
+	n, err := strconv.Atoi(string(p.input[start:p.pos]))
+	if err != nil {
+		return 0, p.errorf("malformed integer: %v", err)
+	}
+
+	return n, nil
+}
+
+// beamString returns the tuple element at the index as a string. Atoms, strings & binaries are supported.
+func beamString(t beamTuple, i int) string {
+	if i >= len(t) {
+		return ""
+	}
+	switch v := t[i].(type) {
+	case string:
+		return v
+	case beamAtom:
+		return string(v)
+	}
+
+	return ""
+}
+
+func sortedBEAMKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
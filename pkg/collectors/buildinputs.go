@@ -0,0 +1,92 @@
+package collectors
+
+import (
+	"context"
+	"errors"
+	"os"
+	fp "path/filepath"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	log "github.com/sirupsen/logrus"
+	"github.com/vinted/sbomsftw/pkg/bomtools"
+)
+
+// Files pinning inputs of hermetic Nix & Bazel builds, parsed natively without either of them.
+var buildInputsParsers = []struct {
+	filename string
+	parse    func([]byte) (*cdx.BOM, error)
+}{
+	{filename: "flake.lock", parse: parseFlakeLock},
+	{filename: "MODULE.bazel.lock", parse: parseModuleBazelLock},
+	{filename: "WORKSPACE", parse: parseBazelWorkspace},
+	{filename: "WORKSPACE.bazel", parse: parseBazelWorkspace},
+}
+
+var errNoBuildInputs = errors.New("no flake.lock, MODULE.bazel.lock or WORKSPACE files could be parsed")
+
+// BuildInputs collects inputs pinned by Nix flakes & Bazel workspaces.
+type BuildInputs struct{}
+
+func NewBuildInputsCollector() BuildInputs {
+	return BuildInputs{}
+}
+
+// MatchLanguageFiles implements LanguageCollector interface.
+func (b BuildInputs) MatchLanguageFiles(isDir bool, filepath string) bool {
+	if isDir {
+		return false
+	}
+	for _, d := range strings.Split(fp.Dir(filepath), string(os.PathSeparator)) {
+		if d == "node_modules" { // Some npm packages ship their Bazel workspaces
+			return false
+		}
+	}
+
+	for _, p := range buildInputsParsers {
+		if fp.Base(filepath) == p.filename {
+			return true
+		}
+	}
+
+	return false
+}
+
+// BootstrapLanguageFiles implements LanguageCollector interface.
+func (b BuildInputs) BootstrapLanguageFiles(_ context.Context, bomRoots []string) []string {
+	return SquashToDirs(bomRoots)
+}
+
+/*
+GenerateBOM implements LanguageCollector interface. Every file in the bom root that pins build inputs is
+parsed natively & the resulting BOMs are merged into one.
+*/
+func (b BuildInputs) GenerateBOM(_ context.Context, bomRoot string) (*cdx.BOM, error) {
+	var boms []*cdx.BOM
+	for _, p := range buildInputsParsers {
+		contents, err := os.ReadFile(fp.Join(bomRoot, p.filename))
+		if err != nil {
+			continue
+		}
+		bom, err := p.parse(contents)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"collector":       b,
+				"collection path": bomRoot,
+				"error":           err,
+			}).Debugf("can't parse %s", p.filename)
+			continue
+		}
+		boms = append(boms, bom)
+	}
+	if len(boms) == 0 {
+		return nil, errNoBuildInputs
+	}
+
+	return bomtools.MergeSBOMs(bomtools.MergeSBOMParam{SBOMs: boms})
+}
+
+// String implements LanguageCollector interface.
+func (b BuildInputs) String() string {
+	return "build inputs collector"
+}
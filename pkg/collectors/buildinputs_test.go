@@ -0,0 +1,141 @@
+package collectors
+
+import (
+	"context"
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildInputsCollector(t *testing.T) {
+	t.Run("match correct package files", func(t *testing.T) {
+		buildInputsCollector := BuildInputs{}
+		assert.True(t, buildInputsCollector.MatchLanguageFiles(false, "flake.lock"))
+		assert.True(t, buildInputsCollector.MatchLanguageFiles(false, "/opt/service/MODULE.bazel.lock"))
+		assert.True(t, buildInputsCollector.MatchLanguageFiles(false, "/opt/service/WORKSPACE"))
+		assert.True(t, buildInputsCollector.MatchLanguageFiles(false, "/opt/service/WORKSPACE.bazel"))
+		assert.False(t, buildInputsCollector.MatchLanguageFiles(false, "/opt/service/MODULE.bazel"))
+		assert.False(t, buildInputsCollector.MatchLanguageFiles(false, "/opt/service/flake.nix"))
+		assert.False(t, buildInputsCollector.MatchLanguageFiles(false, "/opt/service/node_modules/re2/WORKSPACE"))
+		assert.False(t, buildInputsCollector.MatchLanguageFiles(true, "WORKSPACE"))
+	})
+
+	t.Run("bootstrap language files correctly", func(t *testing.T) {
+		got := BuildInputs{}.BootstrapLanguageFiles(context.Background(), []string{
+			"/tmp/some-random-dir/MODULE.bazel.lock",
+			"/tmp/some-random-dir/WORKSPACE",
+		})
+		assert.Equal(t, []string{"/tmp/some-random-dir"}, got)
+	})
+
+	t.Run("implement Stringer correctly", func(t *testing.T) {
+		assert.Equal(t, "build inputs collector", BuildInputs{}.String())
+	})
+
+	t.Run("generate BOM natively from flake.lock", func(t *testing.T) {
+		got, err := BuildInputs{}.GenerateBOM(context.Background(), "../../integration/test/nix")
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []string{
+			"pkg:generic/git.example.com/nix/home-manager@5d151429e1e79107acf6d06dcc5ace4e642ec239",
+			"pkg:generic/releases.example.com/sops-nix/sops-nix-0.8.1.tar.gz",
+			"pkg:github/NixOS/nixpkgs@a62e6edd6d5e1fa0329b8653c801147986f8d446",
+			"pkg:github/nix-systems/default@da67096a3b9bf56a91d16901293e51ba5b49a27e",
+			"pkg:github/numtide/flake-utils@b1d9ab70662946ef0850d488da1c9019f3a9752a",
+		}, purls(t, got))
+
+		components := componentsByPURL(t, got)
+		nixpkgs := components["pkg:github/NixOS/nixpkgs@a62e6edd6d5e1fa0329b8653c801147986f8d446"]
+		assert.Empty(t, nixpkgs.Hashes)
+		assert.Equal(t, &[]cdx.Property{
+			{Name: "sbomsftw:nix:narHash", Value: "sha256-oamiKNfr2MS6yH64rUn99mIZjc45nGJlj9eGth/3Xuw="},
+			{Name: "sbomsftw:nix:input", Value: "nixpkgs"},
+			{Name: "sbomsftw:nix:ref", Value: "nixos-24.05"},
+		}, nixpkgs.Properties)
+		assert.Equal(t, &[]cdx.ExternalReference{{
+			Type: cdx.ERTypeVCS,
+			URL:  "https://github.com/NixOS/nixpkgs#a62e6edd6d5e1fa0329b8653c801147986f8d446",
+		}}, nixpkgs.ExternalReferences)
+		assert.Equal(t, &[]cdx.ExternalReference{{
+			Type: cdx.ERTypeDistribution,
+			URL:  "https://releases.example.com/sops-nix/sops-nix-0.8.1.tar.gz",
+		}}, components["pkg:generic/releases.example.com/sops-nix/sops-nix-0.8.1.tar.gz"].ExternalReferences)
+	})
+
+	t.Run("generate BOM natively from MODULE.bazel.lock", func(t *testing.T) {
+		got, err := BuildInputs{}.GenerateBOM(context.Background(), "../../integration/test/bazel/bzlmod")
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []string{
+			"pkg:bazel/abseil-cpp@20230802.0",
+			"pkg:bazel/acme_rules@1.2.0",
+			"pkg:bazel/rules_cc@0.0.9",
+			"pkg:generic/zlib@1.3",
+		}, purls(t, got))
+
+		components := componentsByPURL(t, got)
+		assert.Equal(t, &[]cdx.Property{{
+			Name:  "sbomsftw:bazel:sourceJsonSha256",
+			Value: "1f1ba6fea244b616de4a554a0f4983c91a9301640c8fe0dd1d410254115c8430",
+		}}, components["pkg:bazel/rules_cc@0.0.9"].Properties)
+		assert.Empty(t, *components["pkg:bazel/rules_cc@0.0.9"].ExternalReferences)
+		assert.Equal(t, &[]cdx.ExternalReference{{
+			Type: cdx.ERTypeDistribution,
+			URL:  "https://registry.example.com/modules/acme_rules/1.2.0/source.json",
+		}}, components["pkg:bazel/acme_rules@1.2.0"].ExternalReferences)
+
+		zlib := components["pkg:generic/zlib@1.3"]
+		assert.Equal(t, &[]cdx.Hash{{
+			Algorithm: cdx.HashAlgoSHA256,
+			Value:     "ff0ba4c292013dbc27530b3a81e1f9a813cd39de01ca5e0f8bf355702efa593e",
+		}}, zlib.Hashes)
+		assert.Equal(t, &[]cdx.ExternalReference{{
+			Type: cdx.ERTypeDistribution,
+			URL:  "https://zlib.net/zlib-1.3.tar.gz",
+		}}, zlib.ExternalReferences)
+	})
+
+	t.Run("generate BOM natively from MODULE.bazel.lock with module graph", func(t *testing.T) {
+		got, err := BuildInputs{}.GenerateBOM(context.Background(), "../../integration/test/bazel/bzlmod-legacy")
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"pkg:bazel/bazel_skylib@1.4.1"}, purls(t, got))
+		assert.Equal(t, &[]cdx.Hash{{
+			Algorithm: cdx.HashAlgoSHA256,
+			Value:     "b8a1527901774180afc798aeb28c4634bdccf19c4d98e7bdd1ce79d1fe9aaad7",
+		}}, componentsByPURL(t, got)["pkg:bazel/bazel_skylib@1.4.1"].Hashes)
+	})
+
+	t.Run("generate BOM natively from WORKSPACE", func(t *testing.T) {
+		got, err := BuildInputs{}.GenerateBOM(context.Background(), "../../integration/test/bazel/workspace")
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []string{
+			"pkg:generic/io_bazel_rules_go@0.41.0",
+			"pkg:generic/jq_linux_amd64@1.7.1",
+			"pkg:generic/zlib@1.3",
+			"pkg:github/acme/bazel-rules@0f6c1d26a1e8d8bcd4d6d2d4e37a9b6cf3f8c9b0",
+			"pkg:github/nlohmann/json@960b763ecd144f156d05ec61f577b04107290137",
+		}, purls(t, got))
+
+		components := componentsByPURL(t, got)
+		assert.Equal(t, &[]cdx.Property{
+			{Name: "sbomsftw:bazel:repository", Value: "io_bazel_rules_go"},
+		}, components["pkg:generic/io_bazel_rules_go@0.41.0"].Properties)
+		assert.Equal(t, &[]cdx.Hash{{
+			Algorithm: cdx.HashAlgoSHA256,
+			Value:     "02bd175f329720378ce83dd56a1b6b1f5291a60182d6c54b5e0d1e8d248a267a",
+		}}, components["pkg:github/nlohmann/json@960b763ecd144f156d05ec61f577b04107290137"].Hashes)
+		assert.Equal(t, &[]cdx.ExternalReference{{
+			Type: cdx.ERTypeVCS,
+			URL:  "https://github.com/acme/bazel-rules.git#0f6c1d26a1e8d8bcd4d6d2d4e37a9b6cf3f8c9b0",
+		}}, components["pkg:github/acme/bazel-rules@0f6c1d26a1e8d8bcd4d6d2d4e37a9b6cf3f8c9b0"].ExternalReferences)
+	})
+
+	t.Run("fail when no build inputs can be parsed", func(t *testing.T) {
+		_, err := BuildInputs{}.GenerateBOM(context.Background(), t.TempDir())
+		assert.ErrorIs(t, err, errNoBuildInputs)
+	})
+}
@@ -0,0 +1,191 @@
+package collectors
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+// bunLockfile represents text bun.lock files, written by Bun 1.1.39 & newer.
+type bunLockfile struct {
+	LockfileVersion int                          `json:"lockfileVersion"`
+	Workspaces      map[string]bunManifest       `json:"workspaces"`
+	Packages        map[string][]json.RawMessage `json:"packages"`
+}
+
+// bunManifest holds dependencies of a workspace package or a locked package.
+type bunManifest struct {
+	Dependencies         map[string]string `json:"dependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+	PeerDependencies     map[string]string `json:"peerDependencies"`
+}
+
+/*
+bunPackage is a single entry of the packages map. Entries are arrays, which start with a name@version
+identifier. Packages from npm registries also store the registry URL (empty for the default registry),
+their manifest & an integrity hash, while git & tarball packages store only their manifest. E.g.
+
+	"react": ["react@18.2.0", "", { "dependencies": { "loose-envify": "^1.1.0" } }, "sha512-..."]
+*/
+type bunPackage struct {
+	name, version, registry, integrity string
+	manifest                           bunManifest
+}
+
+func newBunPackage(entry []json.RawMessage) (bunPackage, bool) {
+	var p bunPackage
+	if len(entry) == 0 {
+		return p, false
+	}
+
+	var identifier string
+	if err := json.Unmarshal(entry[0], &identifier); err != nil {
+		return p, false
+	}
+	p.name, p.version = splitYarnDescriptor(identifier)
+
+	for _, field := range entry[1:] {
+		var value any
+		if err := json.Unmarshal(field, &value); err != nil {
+			continue
+		}
+		switch v := value.(type) {
+		case map[string]any:
+			_ = json.Unmarshal(field, &p.manifest)
+		case string:
+			if strings.Contains(v, "://") {
+				p.registry = v
+			} else if hashesFromSRI(v) != nil {
+				p.integrity = v
+			}
+		}
+	}
+
+	return p, true
+}
+
+/*
+stripTrailingCommas removes trailing commas of objects & arrays, which are allowed by the JSONC flavour
+that bun.lock files are written in.
+*/
+func stripTrailingCommas(contents []byte) []byte {
+	var (
+		stripped         bytes.Buffer
+		inString, escape bool
+	)
+
+	for i := 0; i < len(contents); i++ {
+		c := contents[i]
+		switch {
+		case escape:
+			escape = false
+		case inString && c == '\\':
+			escape = true
+		case c == '"':
+			inString = !inString
+		case !inString && c == ',':
+			next := bytes.TrimLeft(contents[i+1:], " \t\r\n")
+			if len(next) > 0 && (next[0] == '}' || next[0] == ']') {
+				continue
+			}
+		}
+		stripped.WriteByte(c)
+	}
+
+	return stripped.Bytes()
+}
+
+/*
+bunScopes walks the dependency graph of a bun.lock file from its workspace packages & returns scopes of locked
+packages, keyed by their packages map keys. Packages reachable through dependencies & peer dependencies are
+required, while packages reachable only through dev or optional dependencies are optional.
+*/
+func bunScopes(workspaces map[string]bunManifest, packages map[string]bunPackage) map[string]cdx.Scope {
+	// Nested packages are keyed by their parent's key & their name. E.g. loose-envify/js-tokens
+	resolve := func(parent, name string) (string, bool) {
+		for parent != "" {
+			if _, ok := packages[parent+"/"+name]; ok {
+				return parent + "/" + name, true
+			}
+			i := strings.LastIndex(parent, "/")
+			if i == -1 {
+				break
+			}
+			parent = parent[:i]
+			if strings.HasPrefix(parent, "@") && !strings.Contains(parent, "/") { // Parent was a scoped package
+				break
+			}
+		}
+		_, ok := packages[name]
+
+		return name, ok
+	}
+
+	scopes := make(map[string]cdx.Scope)
+	var visit func(parent string, dependencies map[string]string, scope cdx.Scope)
+	visit = func(parent string, dependencies map[string]string, scope cdx.Scope) {
+		for _, name := range sortedKeys(dependencies) {
+			key, ok := resolve(parent, name)
+			if !ok {
+				continue
+			}
+			if _, visited := scopes[key]; visited {
+				continue
+			}
+			scopes[key] = scope
+			manifest := packages[key].manifest
+			visit(key, manifest.Dependencies, scope)
+			visit(key, manifest.PeerDependencies, scope)
+			visit(key, manifest.OptionalDependencies, cdx.ScopeOptional)
+		}
+	}
+
+	// Required packages must be visited first, so that they aren't marked as optional
+	for _, w := range sortedKeys(workspaces) {
+		visit("", workspaces[w].Dependencies, cdx.ScopeRequired)
+		visit("", workspaces[w].PeerDependencies, cdx.ScopeRequired)
+	}
+	for _, w := range sortedKeys(workspaces) {
+		visit("", workspaces[w].OptionalDependencies, cdx.ScopeOptional)
+		visit("", workspaces[w].DevDependencies, cdx.ScopeOptional)
+	}
+
+	return scopes
+}
+
+/*
+parseBunLockfile converts text bun.lock contents into pkg:npm components. Scopes are derived from the
+dependency graph, packages unreachable from workspace packages default to required. Workspace, local file
+& link packages are skipped. The binary bun.lockb format isn't supported.
+*/
+func parseBunLockfile(contents []byte) ([]cdx.Component, error) {
+	var lockfile bunLockfile
+	if err := json.Unmarshal(stripTrailingCommas(contents), &lockfile); err != nil {
+		return nil, fmt.Errorf("can't parse bun.lock: %w", err)
+	}
+
+	packages := make(map[string]bunPackage, len(lockfile.Packages))
+	for key, entry := range lockfile.Packages {
+		if p, ok := newBunPackage(entry); ok {
+			packages[key] = p
+		}
+	}
+	scopes := bunScopes(lockfile.Workspaces, packages)
+
+	components := make([]cdx.Component, 0, len(packages))
+	for key, p := range packages {
+		if strings.HasPrefix(p.version, "workspace:") {
+			continue
+		}
+		optional := scopes[key] == cdx.ScopeOptional
+		if c, ok := npmComponent(p.name, p.version, p.registry, p.integrity, false, optional); ok {
+			components = append(components, c)
+		}
+	}
+
+	return uniqueComponents(components), nil
+}
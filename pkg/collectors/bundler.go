@@ -0,0 +1,149 @@
+package collectors
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	log "github.com/sirupsen/logrus"
+)
+
+// gemSpec is a single resolved gem inside one of the Gemfile.lock source sections.
+type gemSpec struct {
+	name, version, platform string
+	source                  string // GEM, GIT or PATH
+	remote, revision        string
+}
+
+/*
+parseGemSpec parses a single gem specification line. E.g. given the following input:
+
+	nokogiri (1.13.10-x86_64-linux)
+
+this function will return a gemSpec with name: nokogiri, version: 1.13.10, platform: x86_64-linux
+*/
+func parseGemSpec(line string) (gemSpec, bool) {
+	name, rest, ok := strings.Cut(strings.TrimSpace(line), " (")
+	if !ok {
+		return gemSpec{}, false
+	}
+	version := strings.TrimSuffix(rest, ")")
+	version, platform, _ := strings.Cut(version, "-")
+
+	return gemSpec{name: name, version: version, platform: platform}, true
+}
+
+/*
+parseGemfileLock converts Gemfile.lock contents into pkg:gem components. Gems from the GEM & GIT sections
+are collected, gems in PATH sections are local to the repository and skipped. Gems listed in the DEPENDENCIES
+section are marked as direct dependencies, bundler itself is added from the BUNDLED WITH section & checksums
+are taken from the CHECKSUMS section when present. PLATFORMS & RUBY VERSION are recorded as BOM properties.
+*/
+func parseGemfileLock(contents []byte) (*cdx.BOM, error) {
+	var (
+		specs       []gemSpec
+		current     gemSpec // Source section currently being parsed
+		section     string
+		direct      = make(map[string]bool)
+		checksums   = make(map[string]string)
+		platforms   []string
+		rubyVersion string
+	)
+
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if !strings.HasPrefix(line, " ") { // Section header
+			section = strings.TrimSpace(line)
+			current = gemSpec{source: section}
+			continue
+		}
+
+		indentation := len(line) - len(strings.TrimLeft(line, " "))
+		trimmed := strings.TrimSpace(line)
+
+		switch section {
+		case "GEM", "GIT", "PATH":
+			switch {
+			case indentation == 2 && strings.HasPrefix(trimmed, "remote:"):
+				current.remote = strings.TrimSpace(strings.TrimPrefix(trimmed, "remote:"))
+			case indentation == 2 && strings.HasPrefix(trimmed, "revision:"):
+				current.revision = strings.TrimSpace(strings.TrimPrefix(trimmed, "revision:"))
+			case indentation == 4: // Nested dependencies of specs are indented with 6 spaces
+				spec, ok := parseGemSpec(trimmed)
+				if !ok {
+					continue
+				}
+				spec.source, spec.remote, spec.revision = current.source, current.remote, current.revision
+				specs = append(specs, spec)
+			}
+		case "DEPENDENCIES":
+			name, _, _ := strings.Cut(trimmed, " ")
+			direct[strings.TrimSuffix(name, "!")] = true
+		case "PLATFORMS":
+			platforms = append(platforms, trimmed)
+		case "RUBY VERSION":
+			rubyVersion = trimmed
+		case "BUNDLED WITH":
+			specs = append(specs, gemSpec{name: "bundler", version: trimmed, source: section})
+		case "CHECKSUMS":
+			spec, checksum, ok := strings.Cut(trimmed, ") ")
+			if !ok {
+				continue
+			}
+			checksums[spec+")"] = checksum
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("can't parse Gemfile.lock: %w", err)
+	}
+
+	components := make([]cdx.Component, 0, len(specs))
+	for _, spec := range specs {
+		if spec.source == "PATH" {
+			log.WithField("gem", spec.name).Debugf("skipping local gem from %s", spec.remote)
+			continue
+		}
+
+		purl := packageURL("gem", "", spec.name, spec.version, qualifier{key: "platform", value: spec.platform})
+		component := newLibraryComponent(purl, spec.name, spec.version)
+		addProperty(&component, "bundler:indirect", fmt.Sprintf("%t", !direct[spec.name]))
+
+		if spec.source == "GIT" {
+			vcsURL := spec.remote
+			if spec.revision != "" {
+				vcsURL += "#" + spec.revision
+			}
+			addExternalReference(&component, cdx.ERTypeVCS, vcsURL)
+		}
+
+		fullVersion := spec.version
+		if spec.platform != "" {
+			fullVersion += "-" + spec.platform
+		}
+		algorithm, digest, _ := strings.Cut(checksums[spec.name+" ("+fullVersion+")"], "=")
+		if algorithm == "sha256" {
+			addHashes(&component, cdx.Hash{Algorithm: cdx.HashAlgoSHA256, Value: digest})
+		}
+		components = append(components, component)
+	}
+
+	bom := bomFromComponents(uniqueComponents(components))
+	properties := make([]cdx.Property, 0, len(platforms)+1)
+	for _, p := range platforms {
+		properties = append(properties, cdx.Property{Name: propertyPrefix + "bundler:platform", Value: p})
+	}
+	if rubyVersion != "" {
+		properties = append(properties, cdx.Property{Name: propertyPrefix + "bundler:ruby", Value: rubyVersion})
+	}
+	if len(properties) > 0 {
+		bom.Properties = &properties
+	}
+
+	return bom, nil
+}
@@ -0,0 +1,118 @@
+package collectors
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+/*
+cargoLockfile represents Cargo.lock files, versions 1 through 4. Version 1 lockfiles created by
+ancient cargo releases store the root package in a separate table.
+*/
+type cargoLockfile struct {
+	Root     *cargoPackage     `toml:"root"`
+	Packages []cargoPackage    `toml:"package"`
+	Metadata map[string]string `toml:"metadata"` // Version 1 checksums
+}
+
+type cargoPackage struct {
+	Name         string   `toml:"name"`
+	Version      string   `toml:"version"`
+	Source       string   `toml:"source"`
+	Checksum     string   `toml:"checksum"`
+	Dependencies []string `toml:"dependencies"`
+}
+
+// sourceKind tells registry, git & path (local) sources apart.
+func (p cargoPackage) sourceKind() string {
+	switch {
+	case p.Source == "":
+		return "path"
+	case strings.HasPrefix(p.Source, "git+"):
+		return "git"
+	default:
+		return "registry"
+	}
+}
+
+func (p cargoPackage) purl() string {
+	return packageURL("cargo", "", p.Name, p.Version)
+}
+
+/*
+resolveCargoDependency finds the package referenced from a dependencies array. Depending on the lockfile
+version & ambiguity, references come in three forms:
+
+	serde
+	serde 1.0.136
+	serde 1.0.136 (registry+https://github.com/rust-lang/crates.io-index)
+*/
+func resolveCargoDependency(packages []cargoPackage, reference string) (cargoPackage, bool) {
+	name, rest, _ := strings.Cut(reference, " ")
+	version, source, _ := strings.Cut(rest, " ")
+	source = strings.TrimSuffix(strings.TrimPrefix(source, "("), ")")
+
+	for _, p := range packages {
+		if p.Name != name || (version != "" && p.Version != version) || (source != "" && p.Source != source) {
+			continue
+		}
+		return p, true
+	}
+
+	return cargoPackage{}, false
+}
+
+/*
+parseCargoLockfile converts Cargo.lock contents into pkg:cargo components & preserves the dependency graph
+encoded in the lockfile as CycloneDX dependencies. Package checksums are recorded as SHA-256 hashes - version 1
+lockfiles store them in the metadata table, newer versions store them next to every package.
+*/
+func parseCargoLockfile(contents []byte) (*cdx.BOM, error) {
+	var lockfile cargoLockfile
+	if _, err := toml.Decode(string(contents), &lockfile); err != nil {
+		return nil, fmt.Errorf("can't parse Cargo.lock: %w", err)
+	}
+	if lockfile.Root != nil {
+		lockfile.Packages = append(lockfile.Packages, *lockfile.Root)
+	}
+
+	components := make([]cdx.Component, 0, len(lockfile.Packages))
+	dependencies := make([]cdx.Dependency, 0, len(lockfile.Packages))
+
+	for _, p := range lockfile.Packages {
+		component := newLibraryComponent(p.purl(), p.Name, p.Version)
+
+		checksum := p.Checksum
+		if checksum == "" {
+			checksum = lockfile.Metadata[fmt.Sprintf("checksum %s %s (%s)", p.Name, p.Version, p.Source)]
+		}
+		if checksum != "" && checksum != "<none>" {
+			addHashes(&component, cdx.Hash{Algorithm: cdx.HashAlgoSHA256, Value: checksum})
+		}
+
+		addProperty(&component, "cargo:source", p.sourceKind())
+		if p.sourceKind() == "git" {
+			// E.g. git+https://github.com/tokio-rs/tokio?branch=master#2ce9e6ed8a7e4d1d4ef5c8d1c3e4b5a6f7e8d9c0
+			repository, commit, _ := strings.Cut(strings.TrimPrefix(p.Source, "git+"), "#")
+			repository, _, _ = strings.Cut(repository, "?")
+			addExternalReference(&component, cdx.ERTypeVCS, repository+"#"+commit)
+		}
+		components = append(components, component)
+
+		dependsOn := make([]string, 0, len(p.Dependencies))
+		for _, reference := range p.Dependencies {
+			if dependency, ok := resolveCargoDependency(lockfile.Packages, reference); ok {
+				dependsOn = append(dependsOn, dependency.purl())
+			}
+		}
+		dependencies = append(dependencies, cdx.Dependency{Ref: p.purl(), Dependencies: &dependsOn})
+	}
+
+	bom := bomFromComponents(uniqueComponents(components))
+	bom.Dependencies = &dependencies
+
+	return bom, nil
+}
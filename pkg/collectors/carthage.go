@@ -0,0 +1,64 @@
+package collectors
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"path"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+/*
+parseCartfileResolved converts Cartfile.resolved contents into components. GitHub & git dependencies
+are source repositories, so they are mapped to pkg:swift components. Binary frameworks are mapped to
+pkg:generic components referencing their download location. E.g.
+
+	github "Alamofire/Alamofire" "5.6.4"
+	git "https://github.com/onevcat/Kingfisher.git" "7.6.2"
+	binary "https://dl.google.com/geosdk/GoogleMaps.json" "7.4.0"
+*/
+func parseCartfileResolved(contents []byte) (*cdx.BOM, error) {
+	var components []cdx.Component
+
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("can't parse Cartfile.resolved: malformed line %q", line)
+		}
+		origin, location, version := fields[0], strings.Trim(fields[1], `"`), strings.Trim(fields[2], `"`)
+
+		var component cdx.Component
+		switch origin {
+		case "github":
+			if !strings.Contains(location, "://") { // GitHub shorthand. E.g. Alamofire/Alamofire
+				location = "https://github.com/" + location
+			}
+			fallthrough
+		case "git":
+			name := strings.TrimSuffix(path.Base(location), ".git")
+			component = newLibraryComponent(swiftPURL(location, version), name, version)
+			addExternalReference(&component, cdx.ERTypeVCS, location)
+		case "binary":
+			name := strings.TrimSuffix(path.Base(location), ".json")
+			component = newLibraryComponent(packageURL("generic", "", name, version), name, version)
+			addExternalReference(&component, cdx.ERTypeDistribution, location)
+		default:
+			return nil, fmt.Errorf("can't parse Cartfile.resolved: unknown origin %q", origin)
+		}
+		component.Scope = cdx.ScopeRequired
+		components = append(components, component)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("can't parse Cartfile.resolved: %w", err)
+	}
+
+	return bomFromComponents(uniqueComponents(components)), nil
+}
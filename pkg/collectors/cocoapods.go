@@ -0,0 +1,78 @@
+package collectors
+
+import (
+	"fmt"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"gopkg.in/yaml.v3"
+)
+
+// podfileLock represents Podfile.lock files.
+type podfileLock struct {
+	Pods            []interface{}                `yaml:"PODS"`
+	ExternalSources map[string]map[string]string `yaml:"EXTERNAL SOURCES"`
+	CheckoutOptions map[string]map[string]string `yaml:"CHECKOUT OPTIONS"`
+}
+
+/*
+parsePodSpec parses a single pod entry of the PODS section. E.g. given the following input:
+
+	Firebase/Analytics (10.9.0)
+
+this function will return: "Firebase", "10.9.0". Subspecs are squashed into their root pod.
+*/
+func parsePodSpec(spec string) (string, string, bool) {
+	name, version, ok := strings.Cut(strings.TrimSpace(spec), " (")
+	if !ok {
+		return "", "", false
+	}
+	name, _, _ = strings.Cut(name, "/")
+
+	return name, strings.TrimSuffix(version, ")"), true
+}
+
+/*
+parsePodfileLock converts Podfile.lock contents into pkg:cocoapods components. Pods installed from local
+paths are skipped, pods installed from git repositories reference the checked out commit.
+*/
+func parsePodfileLock(contents []byte) (*cdx.BOM, error) {
+	var lockfile podfileLock
+	if err := yaml.Unmarshal(contents, &lockfile); err != nil {
+		return nil, fmt.Errorf("can't parse Podfile.lock: %w", err)
+	}
+
+	components := make([]cdx.Component, 0, len(lockfile.Pods))
+	for _, entry := range lockfile.Pods {
+		var spec string
+		switch pod := entry.(type) {
+		case string: // Pods without dependencies
+			spec = pod
+		case map[string]interface{}: // Pods with dependencies
+			for s := range pod {
+				spec = s
+			}
+		}
+
+		name, version, ok := parsePodSpec(spec)
+		if !ok {
+			continue
+		}
+		if _, local := lockfile.ExternalSources[name][":path"]; local {
+			continue
+		}
+
+		component := newLibraryComponent(packageURL("cocoapods", "", name, version), name, version)
+		component.Scope = cdx.ScopeRequired
+		if checkout, ok := lockfile.CheckoutOptions[name]; ok && checkout[":git"] != "" {
+			vcsURL := checkout[":git"]
+			if commit := checkout[":commit"]; commit != "" {
+				vcsURL += "#" + commit
+			}
+			addExternalReference(&component, cdx.ERTypeVCS, vcsURL)
+		}
+		components = append(components, component)
+	}
+
+	return bomFromComponents(uniqueComponents(components)), nil
+}
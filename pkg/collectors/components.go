@@ -0,0 +1,204 @@
+package collectors
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"net/url"
+	"sort"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+// Prefix for CycloneDX property names set by native lockfile parsers.
+const propertyPrefix = "sbomsftw:"
+
+// qualifier is a single key=value Package URL qualifier. E.g. arch=x86_64
+type qualifier struct {
+	key, value string
+}
+
+/*
+packageURL formats a Package URL as described in https://github.com/package-url/purl-spec
+Namespace & qualifiers are optional. E.g. given the following input:
+
+	packageURL("npm", "@babel", "core", "7.16.0")
+
+this function will return: pkg:npm/%40babel/core@7.16.0
+*/
+func packageURL(purlType, namespace, name, version string, qualifiers ...qualifier) string {
+	escape := func(s string) string {
+		return strings.ReplaceAll(url.PathEscape(s), "@", "%40")
+	}
+
+	var b strings.Builder
+	b.WriteString("pkg:" + purlType + "/")
+	if namespace != "" {
+		segments := strings.Split(strings.Trim(namespace, "/"), "/")
+		for i := range segments {
+			segments[i] = escape(segments[i])
+		}
+		b.WriteString(strings.Join(segments, "/") + "/")
+	}
+	b.WriteString(escape(name))
+	if version != "" {
+		b.WriteString("@" + escape(version))
+	}
+
+	var nonEmpty []qualifier
+	for _, q := range qualifiers {
+		if q.value != "" {
+			nonEmpty = append(nonEmpty, q)
+		}
+	}
+	if len(nonEmpty) == 0 {
+		return b.String()
+	}
+	sort.Slice(nonEmpty, func(i, j int) bool { return nonEmpty[i].key < nonEmpty[j].key })
+
+	encoded := make([]string, 0, len(nonEmpty))
+	for _, q := range nonEmpty {
+		value := strings.ReplaceAll(url.QueryEscape(q.value), "+", "%20")
+		encoded = append(encoded, strings.ToLower(q.key)+"="+value)
+	}
+
+	return b.String() + "?" + strings.Join(encoded, "&")
+}
+
+// newLibraryComponent creates a library component identified by the Package URL given.
+func newLibraryComponent(purl, name, version string) cdx.Component {
+	return cdx.Component{
+		BOMRef:     purl,
+		Type:       cdx.ComponentTypeLibrary,
+		Name:       name,
+		Version:    version,
+		PackageURL: purl,
+	}
+}
+
+// addProperty appends a property prefixed with propertyPrefix to the component. Empty values are skipped.
+func addProperty(c *cdx.Component, name, value string) {
+	if value == "" {
+		return
+	}
+	if c.Properties == nil {
+		c.Properties = &[]cdx.Property{}
+	}
+	*c.Properties = append(*c.Properties, cdx.Property{Name: propertyPrefix + name, Value: value})
+}
+
+/*
+hashesFromSRI converts Subresource Integrity strings to CycloneDX hashes. E.g. given the following input:
+
+	sha512-v2kDEe57lecTulaDIuNTPy3Ry4gLGJ6Z1O3vE1krgXZNrsQ+LFTGHVxVjcXGOhsoa0j9j7ZKOZ7g4w5Y8tbZ8Q==
+
+this function will return a single SHA-512 hash with its value hex encoded. Unsupported algorithms are skipped.
+*/
+func hashesFromSRI(integrity string) []cdx.Hash {
+	algorithms := map[string]cdx.HashAlgorithm{
+		"sha1":   cdx.HashAlgoSHA1,
+		"sha256": cdx.HashAlgoSHA256,
+		"sha384": cdx.HashAlgoSHA384,
+		"sha512": cdx.HashAlgoSHA512,
+	}
+
+	var hashes []cdx.Hash
+	for _, sri := range strings.Fields(integrity) {
+		algorithm, digest, ok := strings.Cut(sri, "-")
+		if !ok {
+			continue
+		}
+		hashAlgorithm, supported := algorithms[algorithm]
+		if !supported {
+			continue
+		}
+		decoded, err := base64.StdEncoding.DecodeString(digest)
+		if err != nil {
+			continue
+		}
+		hashes = append(hashes, cdx.Hash{Algorithm: hashAlgorithm, Value: hex.EncodeToString(decoded)})
+	}
+
+	return hashes
+}
+
+// addHashes appends hashes to the component.
+func addHashes(c *cdx.Component, hashes ...cdx.Hash) {
+	if len(hashes) == 0 {
+		return
+	}
+	if c.Hashes == nil {
+		c.Hashes = &[]cdx.Hash{}
+	}
+	*c.Hashes = append(*c.Hashes, hashes...)
+}
+
+// addExternalReference appends an external reference to the component. Empty URLs are skipped.
+func addExternalReference(c *cdx.Component, refType cdx.ExternalReferenceType, refURL string) {
+	if refURL == "" {
+		return
+	}
+	if c.ExternalReferences == nil {
+		c.ExternalReferences = &[]cdx.ExternalReference{}
+	}
+	*c.ExternalReferences = append(*c.ExternalReferences, cdx.ExternalReference{Type: refType, URL: refURL})
+}
+
+/*
+uniqueComponents filters out components with duplicate Package URLs. The first occurrence wins, however
+if any of the duplicates is required at runtime - the resulting component is required as well.
+*/
+func uniqueComponents(components []cdx.Component) []cdx.Component {
+	indexes := make(map[string]int)
+	unique := make([]cdx.Component, 0, len(components))
+
+	for _, c := range components {
+		i, exists := indexes[c.PackageURL]
+		if !exists {
+			indexes[c.PackageURL] = len(unique)
+			unique = append(unique, c)
+			continue
+		}
+		if c.Scope != cdx.ScopeOptional && unique[i].Scope == cdx.ScopeOptional {
+			unique[i].Scope = c.Scope
+		}
+	}
+
+	return unique
+}
+
+// bomFromComponents wraps components into a new BOM. Components are sorted by their Package URL.
+func bomFromComponents(components []cdx.Component) *cdx.BOM {
+	sort.SliceStable(components, func(i, j int) bool {
+		return components[i].PackageURL < components[j].PackageURL
+	})
+
+	bom := cdx.NewBOM()
+	bom.Components = &components
+
+	return bom
+}
+
+// xmlProperties holds arbitrary child elements of a properties block. E.g. Maven <properties> or MSBuild <PropertyGroup>
+type xmlProperties map[string]string
+
+func (p *xmlProperties) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	*p = make(xmlProperties)
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			var value string
+			if err := d.DecodeElement(&value, &t); err != nil {
+				return err
+			}
+			(*p)[t.Name.Local] = strings.TrimSpace(value)
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package collectors
+
+import (
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// componentsByPURL maps every BOM component to its Package URL. Used for assertions in collector tests.
+func componentsByPURL(t *testing.T, bom *cdx.BOM) map[string]cdx.Component {
+	t.Helper()
+	require.NotNil(t, bom)
+	require.NotNil(t, bom.Components)
+
+	components := make(map[string]cdx.Component)
+	for _, c := range *bom.Components {
+		components[c.PackageURL] = c
+	}
+
+	return components
+}
+
+// purls returns Package URLs of every BOM component.
+func purls(t *testing.T, bom *cdx.BOM) []string {
+	t.Helper()
+	require.NotNil(t, bom)
+	require.NotNil(t, bom.Components)
+
+	var result []string
+	for _, c := range *bom.Components {
+		result = append(result, c.PackageURL)
+	}
+
+	return result
+}
+
+func TestPackageURL(t *testing.T) {
+	assert.Equal(t, "pkg:npm/lodash@4.17.21", packageURL("npm", "", "lodash", "4.17.21"))
+	assert.Equal(t, "pkg:npm/%40babel/core@7.16.0", packageURL("npm", "@babel", "core", "7.16.0"))
+	assert.Equal(t, "pkg:golang/github.com/spf13/cobra", packageURL("golang", "github.com/spf13", "cobra", ""))
+	assert.Equal(t,
+		"pkg:gem/nokogiri@1.13.10?arch=x86_64-linux&platform=ruby%20mri",
+		packageURL("gem", "", "nokogiri", "1.13.10",
+			qualifier{key: "platform", value: "ruby mri"}, qualifier{key: "arch", value: "x86_64-linux"},
+			qualifier{key: "empty"},
+		),
+	)
+}
+
+func TestHashesFromSRI(t *testing.T) {
+	got := hashesFromSRI("sha1-VgiurfwAvmwpAd9fmGF4jeDVl8g= md5-invalid sha512-not-base64")
+	assert.Equal(t, []cdx.Hash{
+		{Algorithm: cdx.HashAlgoSHA1, Value: "5608aeadfc00be6c2901df5f9861788de0d597c8"},
+	}, got)
+}
+
+func TestUniqueComponents(t *testing.T) {
+	dev := newLibraryComponent("pkg:npm/ms@2.0.0", "ms", "2.0.0")
+	dev.Scope = cdx.ScopeOptional
+	required := newLibraryComponent("pkg:npm/ms@2.0.0", "ms", "2.0.0")
+	required.Scope = cdx.ScopeRequired
+	other := newLibraryComponent("pkg:npm/debug@2.6.9", "debug", "2.6.9")
+
+	got := uniqueComponents([]cdx.Component{dev, other, required})
+	require.Len(t, got, 2)
+	assert.Equal(t, cdx.ScopeRequired, got[0].Scope)
+	assert.Equal(t, "pkg:npm/debug@2.6.9", got[1].PackageURL)
+}
@@ -0,0 +1,77 @@
+package collectors
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+// composerLockfile represents composer.lock files.
+type composerLockfile struct {
+	Packages    []composerPackage `json:"packages"`
+	PackagesDev []composerPackage `json:"packages-dev"`
+}
+
+type composerPackage struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Source  struct {
+		Type      string `json:"type"`
+		URL       string `json:"url"`
+		Reference string `json:"reference"`
+	} `json:"source"`
+	Dist struct {
+		Type      string `json:"type"`
+		URL       string `json:"url"`
+		Reference string `json:"reference"`
+		Shasum    string `json:"shasum"`
+	} `json:"dist"`
+}
+
+/*
+parseComposerLockfile converts composer.lock contents into pkg:composer components. Packages from the
+packages-dev section are optional. Source repositories are recorded as VCS references & dist archives as
+distribution references along with their SHA-1 checksums. Packages installed from local paths are skipped.
+*/
+func parseComposerLockfile(contents []byte) (*cdx.BOM, error) {
+	var lockfile composerLockfile
+	if err := json.Unmarshal(contents, &lockfile); err != nil {
+		return nil, fmt.Errorf("can't parse composer.lock: %w", err)
+	}
+
+	components := make([]cdx.Component, 0, len(lockfile.Packages)+len(lockfile.PackagesDev))
+	collect := func(packages []composerPackage, scope cdx.Scope) {
+		for _, p := range packages {
+			if p.Dist.Type == "path" {
+				continue
+			}
+
+			vendor, name, ok := strings.Cut(p.Name, "/") // E.g. laravel/framework
+			if !ok {
+				vendor, name = "", p.Name
+			}
+			component := newLibraryComponent(packageURL("composer", vendor, name, p.Version), p.Name, p.Version)
+			component.Group = vendor
+			component.Scope = scope
+
+			if p.Dist.Shasum != "" {
+				addHashes(&component, cdx.Hash{Algorithm: cdx.HashAlgoSHA1, Value: p.Dist.Shasum})
+			}
+			if p.Source.URL != "" {
+				vcsURL := p.Source.URL
+				if p.Source.Reference != "" {
+					vcsURL += "#" + p.Source.Reference
+				}
+				addExternalReference(&component, cdx.ERTypeVCS, vcsURL)
+			}
+			addExternalReference(&component, cdx.ERTypeDistribution, p.Dist.URL)
+			components = append(components, component)
+		}
+	}
+	collect(lockfile.Packages, cdx.ScopeRequired)
+	collect(lockfile.PackagesDev, cdx.ScopeOptional)
+
+	return bomFromComponents(uniqueComponents(components)), nil
+}
@@ -0,0 +1,196 @@
+package collectors
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+/*
+conanLockfile represents conan.lock files. Conan 1 lockfiles (version 0.4) hold the whole dependency graph,
+while Conan 2 lockfiles (version 0.5) hold flat lists of references.
+*/
+type conanLockfile struct {
+	Version   string `json:"version"`
+	GraphLock *struct {
+		Nodes map[string]conanGraphNode `json:"nodes"`
+	} `json:"graph_lock"`
+	Requires       []string `json:"requires"`
+	BuildRequires  []string `json:"build_requires"`
+	PythonRequires []string `json:"python_requires"`
+	ConfigRequires []string `json:"config_requires"`
+}
+
+type conanGraphNode struct {
+	Ref           string   `json:"ref"`
+	Path          string   `json:"path"` // Set for the consumer, i.e. the conanfile being locked
+	PackageID     string   `json:"package_id"`
+	Prev          string   `json:"prev"`
+	Requires      []string `json:"requires"`
+	BuildRequires []string `json:"build_requires"`
+}
+
+// conanReference is a parsed recipe reference. E.g. openssl/3.1.1@acme/stable#8d2a65f5b1d9ed3b1d1d6d3bdb2c8d06%1685000000.0
+type conanReference struct {
+	name, version, user, channel, revision string
+}
+
+func parseConanReference(reference string) (conanReference, bool) {
+	reference, _, _ = strings.Cut(strings.TrimSpace(reference), "%") // Revision timestamp
+	reference, revision, _ := strings.Cut(reference, "#")
+	reference, userChannel, _ := strings.Cut(reference, "@")
+	name, version, ok := strings.Cut(reference, "/")
+	if !ok || name == "" || version == "" {
+		return conanReference{}, false
+	}
+	user, channel, _ := strings.Cut(userChannel, "/")
+
+	return conanReference{name: name, version: version, user: user, channel: channel, revision: revision}, true
+}
+
+// isRange reports whether the reference holds a version range. E.g. zlib/[>=1.2.11 <2]
+func (r conanReference) isRange() bool {
+	return strings.HasPrefix(r.version, "[")
+}
+
+/*
+conanComponent creates a pkg:conan component. User & channel become qualifiers, while recipe & package
+revisions are recorded as properties. Build, python & config requirements are excluded, since they
+are needed only to build the project.
+*/
+func conanComponent(r conanReference, build bool) cdx.Component {
+	purl := packageURL("conan", "", r.name, r.version,
+		qualifier{key: "user", value: r.user}, qualifier{key: "channel", value: r.channel})
+	component := newLibraryComponent(purl, r.name, r.version)
+	component.Scope = cdx.ScopeRequired
+	if build {
+		component.Scope = cdx.ScopeExcluded
+	}
+	addProperty(&component, "conan:recipeRevision", r.revision)
+
+	return component
+}
+
+/*
+parseConanLockfile converts conan.lock contents into pkg:conan components. Nodes of Conan 1 dependency graphs
+that aren't reachable from the consumer through regular requirements are treated as build requirements.
+*/
+func parseConanLockfile(contents []byte) (*cdx.BOM, error) {
+	var lockfile conanLockfile
+	if err := json.Unmarshal(contents, &lockfile); err != nil {
+		return nil, fmt.Errorf("can't parse conan.lock: %w", err)
+	}
+
+	var components []cdx.Component
+	if lockfile.GraphLock != nil {
+		components = conanGraphComponents(lockfile.GraphLock.Nodes)
+	} else {
+		for _, list := range []struct {
+			references []string
+			build      bool
+		}{
+			{references: lockfile.Requires},
+			{references: lockfile.BuildRequires, build: true},
+			{references: lockfile.PythonRequires, build: true},
+			{references: lockfile.ConfigRequires, build: true},
+		} {
+			for _, reference := range list.references {
+				if r, ok := parseConanReference(reference); ok {
+					components = append(components, conanComponent(r, list.build))
+				}
+			}
+		}
+	}
+	if components == nil {
+		return nil, fmt.Errorf("can't parse conan.lock: no locked references found in version %q", lockfile.Version)
+	}
+
+	return bomFromComponents(uniqueComponents(components)), nil
+}
+
+func conanGraphComponents(nodes map[string]conanGraphNode) []cdx.Component {
+	ids := make([]string, 0, len(nodes))
+	for id := range nodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var queue []string
+	for _, id := range ids {
+		if nodes[id].Path != "" {
+			queue = append(queue, id)
+		}
+	}
+	host := make(map[string]bool)
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if host[id] {
+			continue
+		}
+		host[id] = true
+		queue = append(queue, nodes[id].Requires...)
+	}
+
+	components := make([]cdx.Component, 0, len(nodes))
+	for _, id := range ids {
+		node := nodes[id]
+		r, ok := parseConanReference(node.Ref)
+		if node.Path != "" || !ok {
+			continue
+		}
+		component := conanComponent(r, len(host) > 0 && !host[id])
+		addProperty(&component, "conan:packageRevision", node.Prev)
+		components = append(components, component)
+	}
+
+	return components
+}
+
+/*
+parseConanfileTxt converts requirements of conanfile.txt files into pkg:conan components. Unlike lockfiles,
+conanfile.txt can hold version ranges, those can't be pinned, so such components are left without a version.
+*/
+func parseConanfileTxt(contents []byte) (*cdx.BOM, error) {
+	var (
+		section    string
+		components []cdx.Component
+	)
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.Trim(line, "[]")
+			continue
+		}
+		if section != "requires" && section != "build_requires" && section != "tool_requires" && section != "test_requires" {
+			continue
+		}
+
+		r, ok := parseConanReference(line)
+		if !ok {
+			return nil, fmt.Errorf("can't parse conanfile.txt: malformed reference %q", line)
+		}
+		if r.isRange() {
+			r.version = ""
+		}
+		component := conanComponent(r, section == "build_requires" || section == "tool_requires")
+		if section == "test_requires" {
+			component.Scope = cdx.ScopeOptional
+		}
+		components = append(components, component)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("can't parse conanfile.txt: %w", err)
+	}
+
+	return bomFromComponents(uniqueComponents(components)), nil
+}
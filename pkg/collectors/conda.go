@@ -0,0 +1,191 @@
+package collectors
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// Operators of conda & pip version ranges
+	versionRangePattern = regexp.MustCompile(`[<>!~*|,]`)
+	// Pip requirements in name[extras]==version form
+	pipRequirementPattern = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)\s*(?:\[[^\]]*\])?\s*(.*)$`)
+)
+
+// condaEnvironment represents conda environment.yml files.
+type condaEnvironment struct {
+	Name         string            `yaml:"name"`
+	Channels     []string          `yaml:"channels"`
+	Dependencies []condaDependency `yaml:"dependencies"`
+}
+
+/*
+condaDependency is a single entry of the dependencies list. Conda packages are plain match specs,
+while packages installed with pip are nested in a mapping. E.g.
+
+  - numpy=1.21.5
+  - pip:
+  - requests==2.31.0
+*/
+type condaDependency struct {
+	spec string
+	pip  []string
+}
+
+func (d *condaDependency) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		d.spec = value.Value
+		return nil
+	}
+
+	var nested struct {
+		Pip []string `yaml:"pip"`
+	}
+	if err := value.Decode(&nested); err != nil {
+		return err
+	}
+	d.pip = nested.Pip
+
+	return nil
+}
+
+// condaMatchSpec is a parsed conda package specification.
+type condaMatchSpec struct {
+	channel, name, version, build string
+}
+
+/*
+parseCondaMatchSpec parses conda match specs. Both the = separated & the space separated forms are supported,
+optionally prefixed with a channel. E.g. given the following input:
+
+	conda-forge::numpy=1.21.5=py39h7a0a035_1
+	numpy 1.21.5 py39h7a0a035_1
+
+this function will return a condaMatchSpec with channel: conda-forge (first input only), name: numpy,
+version: 1.21.5 & build: py39h7a0a035_1. Only exact matches are pinned. A single = without a build is a fuzzy
+match (numpy=1.21 matches 1.21.5 as well), so its version is left empty, same as for version ranges (numpy>=1.20).
+*/
+func parseCondaMatchSpec(spec string) condaMatchSpec {
+	var parsed condaMatchSpec
+
+	spec = strings.TrimSpace(spec)
+	if channel, rest, ok := strings.Cut(spec, "::"); ok {
+		parsed.channel, spec = channel, rest
+	}
+
+	i := strings.IndexAny(spec, "=<>!~ ")
+	if i == -1 {
+		parsed.name = spec
+		return parsed
+	}
+	parsed.name = spec[:i]
+
+	constraint := strings.TrimSpace(spec[i:])
+	if versionRangePattern.MatchString(constraint) {
+		return parsed
+	}
+	fields := strings.FieldsFunc(constraint, func(r rune) bool {
+		return r == '=' || unicode.IsSpace(r)
+	})
+	fuzzy := strings.HasPrefix(constraint, "=") && !strings.HasPrefix(constraint, "==") && len(fields) < 2
+	if fuzzy {
+		return parsed
+	}
+	if len(fields) > 0 {
+		parsed.version = fields[0]
+	}
+	if len(fields) > 1 {
+		parsed.build = fields[1]
+	}
+
+	return parsed
+}
+
+/*
+parsePipRequirement parses a single pip requirement, pinned with ==. E.g. given the following input:
+
+	uvicorn[standard]==0.13.4 ; python_version >= "3.7"
+
+this function will return: "uvicorn", "0.13.4". Options (-r, -e), URLs & local paths aren't supported -
+false is returned for those. Version ranges aren't pinned - their version is left empty.
+*/
+func parsePipRequirement(requirement string) (string, string, bool) {
+	requirement, _, _ = strings.Cut(requirement, ";")
+	requirement, _, _ = strings.Cut(requirement, "#")
+	requirement = strings.TrimSpace(requirement)
+	if requirement == "" || strings.HasPrefix(requirement, "-") || strings.Contains(requirement, "/") {
+		return "", "", false
+	}
+
+	matches := pipRequirementPattern.FindStringSubmatch(requirement)
+	if matches == nil {
+		return "", "", false
+	}
+	name, constraint := matches[1], strings.TrimSpace(matches[2])
+	if strings.HasPrefix(constraint, "@") { // Direct references. E.g. name @ https://...
+		return "", "", false
+	}
+
+	version := strings.TrimSpace(strings.TrimPrefix(constraint, "=="))
+	if !strings.HasPrefix(constraint, "==") || versionRangePattern.MatchString(version) {
+		version = ""
+	}
+
+	return name, version, true
+}
+
+/*
+parseCondaEnvironment converts conda environment.yml contents into pkg:conda components with channel & build
+qualifiers. Packages installed with pip inside the environment are converted into pkg:pypi components.
+Channels listed in the environment are recorded as BOM properties instead of qualifiers, because conda
+chooses between them only when resolving the environment.
+*/
+func parseCondaEnvironment(contents []byte) (*cdx.BOM, error) {
+	var environment condaEnvironment
+	if err := yaml.Unmarshal(contents, &environment); err != nil {
+		return nil, fmt.Errorf("can't parse conda environment: %w", err)
+	}
+
+	var components []cdx.Component
+	for _, d := range environment.Dependencies {
+		if d.spec != "" {
+			spec := parseCondaMatchSpec(d.spec)
+			if spec.name == "" {
+				continue
+			}
+			purl := packageURL("conda", "", strings.ToLower(spec.name), spec.version,
+				qualifier{key: "build", value: spec.build},
+				qualifier{key: "channel", value: spec.channel},
+			)
+			component := newLibraryComponent(purl, spec.name, spec.version)
+			component.Scope = cdx.ScopeRequired
+			components = append(components, component)
+		}
+
+		for _, requirement := range d.pip {
+			name, version, ok := parsePipRequirement(requirement)
+			if !ok {
+				continue
+			}
+			component := newLibraryComponent(pypiPURL(name, version), name, version)
+			component.Scope = cdx.ScopeRequired
+			components = append(components, component)
+		}
+	}
+
+	bom := bomFromComponents(uniqueComponents(components))
+	if len(environment.Channels) > 0 {
+		properties := make([]cdx.Property, 0, len(environment.Channels))
+		for _, c := range environment.Channels {
+			properties = append(properties, cdx.Property{Name: propertyPrefix + "conda:channel", Value: c})
+		}
+		bom.Properties = &properties
+	}
+
+	return bom, nil
+}
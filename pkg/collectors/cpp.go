@@ -0,0 +1,101 @@
+package collectors
+
+import (
+	"context"
+	"os"
+	fp "path/filepath"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	log "github.com/sirupsen/logrus"
+	"github.com/vinted/sbomsftw/pkg/bomtools"
+)
+
+// Conan & vcpkg files the collector looks for.
+var cppPackageFiles = []string{
+	"conanfile.txt", "conanfile.py", "conan.lock", "vcpkg.json", "vcpkg-configuration.json",
+}
+
+// CPP collects dependencies of C & C++ projects managed with Conan or vcpkg.
+type CPP struct {
+	executor shellExecutor
+}
+
+func NewCPPCollector() CPP {
+	return CPP{
+		executor: defaultShellExecutor{},
+	}
+}
+
+// MatchLanguageFiles implements LanguageCollector interface.
+func (c CPP) MatchLanguageFiles(isDir bool, filepath string) bool {
+	if isDir {
+		return false
+	}
+	for _, d := range strings.Split(fp.Dir(filepath), string(os.PathSeparator)) {
+		if d == "vcpkg_installed" || d == "ports" { // Ignore installed packages & port manifests
+			return false
+		}
+	}
+
+	for _, f := range cppPackageFiles {
+		if fp.Base(filepath) == f {
+			return true
+		}
+	}
+
+	return false
+}
+
+// BootstrapLanguageFiles implements LanguageCollector interface.
+func (c CPP) BootstrapLanguageFiles(_ context.Context, bomRoots []string) []string {
+	return SquashToDirs(bomRoots)
+}
+
+/*
+GenerateBOM implements LanguageCollector interface. conan.lock (or conanfile.txt when the project isn't locked)
+& vcpkg.json are parsed natively & the resulting BOMs are merged into one. Falls back to cdxgen when
+none of them can be parsed, e.g. for projects with conanfile.py only.
+*/
+func (c CPP) GenerateBOM(ctx context.Context, bomRoot string) (*cdx.BOM, error) {
+	const language = "cpp"
+
+	var boms []*cdx.BOM
+	collect := func(filename string, parse func() (*cdx.BOM, error)) bool {
+		if _, err := os.Stat(fp.Join(bomRoot, filename)); err != nil {
+			return false
+		}
+		bom, err := parse()
+		if err != nil {
+			log.WithFields(log.Fields{
+				"collector":       c,
+				"collection path": bomRoot,
+				"error":           err,
+			}).Debugf("can't parse %s", filename)
+			return false
+		}
+		boms = append(boms, bom)
+		return true
+	}
+	read := func(filename string) []byte {
+		contents, _ := os.ReadFile(fp.Join(bomRoot, filename))
+		return contents
+	}
+
+	if !collect("conan.lock", func() (*cdx.BOM, error) { return parseConanLockfile(read("conan.lock")) }) {
+		collect("conanfile.txt", func() (*cdx.BOM, error) { return parseConanfileTxt(read("conanfile.txt")) })
+	}
+	collect("vcpkg.json", func() (*cdx.BOM, error) {
+		return parseVcpkgManifest(read("vcpkg.json"), read("vcpkg-configuration.json"))
+	})
+	if len(boms) == 0 {
+		return c.executor.bomFromCdxgen(ctx, bomRoot, language, false)
+	}
+
+	return bomtools.MergeSBOMs(bomtools.MergeSBOMParam{SBOMs: boms})
+}
+
+// String implements LanguageCollector interface.
+func (c CPP) String() string {
+	return "cpp collector"
+}
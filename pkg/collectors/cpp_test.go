@@ -0,0 +1,147 @@
+package collectors
+
+import (
+	"context"
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCPPCollector(t *testing.T) {
+	t.Run("match correct package files", func(t *testing.T) {
+		cppCollector := CPP{}
+		for _, f := range []string{
+			"conanfile.txt",
+			"/opt/conanfile.py",
+			"/opt/conan.lock",
+			"/opt/vcpkg.json",
+			"/opt/vcpkg-configuration.json",
+		} {
+			assert.True(t, cppCollector.MatchLanguageFiles(false, f))
+		}
+		assert.False(t, cppCollector.MatchLanguageFiles(false, "/opt/vcpkg_installed/x64-linux/share/zlib/vcpkg.json"))
+		assert.False(t, cppCollector.MatchLanguageFiles(false, "/opt/vcpkg/ports/zlib/vcpkg.json"))
+		assert.False(t, cppCollector.MatchLanguageFiles(false, "/opt/CMakeLists.txt"))
+		assert.False(t, cppCollector.MatchLanguageFiles(true, "conan.lock"))
+	})
+
+	t.Run("bootstrap language files correctly", func(t *testing.T) {
+		got := CPP{}.BootstrapLanguageFiles(context.Background(), []string{
+			"/tmp/some-random-dir/conanfile.txt",
+			"/tmp/some-random-dir/conan.lock",
+			"/tmp/some-random-dir/inner-dir/vcpkg.json",
+		})
+		assert.ElementsMatch(t, []string{"/tmp/some-random-dir", "/tmp/some-random-dir/inner-dir"}, got)
+	})
+
+	t.Run("fall back to cdxgen when nothing can be parsed natively", func(t *testing.T) {
+		const bomRoot = "/tmp/some-random-dir"
+		executor := new(mockShellExecutor)
+		executor.On("bomFromCdxgen", bomRoot, "cpp", false).Return(new(cdx.BOM), nil)
+		_, _ = CPP{executor: executor}.GenerateBOM(context.Background(), bomRoot)
+		executor.AssertExpectations(t)
+	})
+
+	t.Run("generate BOM natively from Conan 1 lockfile", func(t *testing.T) {
+		executor := new(mockShellExecutor)
+		got, err := CPP{executor: executor}.GenerateBOM(context.Background(), "../../integration/test/cpp/conan-v1")
+		require.NoError(t, err)
+		executor.AssertNotCalled(t, "bomFromCdxgen")
+
+		assert.ElementsMatch(t, []string{
+			"pkg:conan/cmake@3.26.4",
+			"pkg:conan/openssl@1.1.1t",
+			"pkg:conan/zlib@1.2.13",
+		}, purls(t, got))
+
+		components := componentsByPURL(t, got)
+		assert.Equal(t, cdx.ScopeRequired, components["pkg:conan/openssl@1.1.1t"].Scope)
+		assert.Equal(t, cdx.ScopeRequired, components["pkg:conan/zlib@1.2.13"].Scope)
+		assert.Equal(t, cdx.ScopeExcluded, components["pkg:conan/cmake@3.26.4"].Scope)
+		assert.Equal(t, &[]cdx.Property{
+			{Name: "sbomsftw:conan:recipeRevision", Value: "13c96f538b52e1600c40b88994de240f"},
+			{Name: "sbomsftw:conan:packageRevision", Value: "b8c0a9b0c5ec3d0b1d2e5f6a7b8c9d0e"},
+		}, components["pkg:conan/zlib@1.2.13"].Properties)
+	})
+
+	t.Run("prefer Conan 2 lockfile over conanfile.txt", func(t *testing.T) {
+		got, err := CPP{}.GenerateBOM(context.Background(), "../../integration/test/cpp/conan-v2")
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []string{
+			"pkg:conan/cmake@3.27.4",
+			"pkg:conan/fmt@10.1.1?channel=stable&user=acme", // User & channel identify packages, so they survive merging
+			"pkg:conan/zlib@1.3",
+		}, purls(t, got))
+
+		components := componentsByPURL(t, got)
+		assert.Equal(t, cdx.ScopeExcluded, components["pkg:conan/cmake@3.27.4"].Scope)
+		assert.Equal(t, &[]cdx.Property{
+			{Name: "sbomsftw:conan:recipeRevision", Value: "2e4e2ca33d5b2f3a6e8a2b4c6d8e0f12"},
+		}, components["pkg:conan/fmt@10.1.1?channel=stable&user=acme"].Properties)
+	})
+
+	t.Run("parse Conan references correctly", func(t *testing.T) {
+		got, err := parseConanLockfile([]byte(`{"version": "0.5", "requires": ["fmt/10.1.1@acme/stable#2e4e2ca3%1693000000.0"]}`))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pkg:conan/fmt@10.1.1?channel=stable&user=acme"}, purls(t, got))
+	})
+
+	t.Run("parse conanfile.txt correctly", func(t *testing.T) {
+		got, err := parseConanfileTxt([]byte("[requires]\nzlib/[>=1.2.11 <2]\nboost/1.83.0\n\n[test_requires]\ngtest/1.14.0\n"))
+		require.NoError(t, err)
+
+		components := componentsByPURL(t, got)
+		assert.ElementsMatch(t, []string{"pkg:conan/boost@1.83.0", "pkg:conan/gtest@1.14.0", "pkg:conan/zlib"}, purls(t, got))
+		assert.Equal(t, cdx.ScopeOptional, components["pkg:conan/gtest@1.14.0"].Scope)
+	})
+
+	t.Run("generate BOM natively from vcpkg.json", func(t *testing.T) {
+		got, err := CPP{}.GenerateBOM(context.Background(), "../../integration/test/cpp/vcpkg")
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []string{
+			"pkg:generic/acme-codec",
+			"pkg:generic/fmt@10.0.0",
+			"pkg:generic/libpng",
+			"pkg:generic/vcpkg-cmake",
+		}, purls(t, got))
+
+		components := componentsByPURL(t, got)
+		assert.Equal(t, cdx.ScopeExcluded, components["pkg:generic/vcpkg-cmake"].Scope)
+		assert.Equal(t, &[]cdx.Property{
+			{Name: "sbomsftw:vcpkg:baseline", Value: "9b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c"},
+			{Name: "sbomsftw:vcpkg:registry", Value: "https://github.com/acme/vcpkg-registry"},
+			{Name: "sbomsftw:vcpkg:platform", Value: "linux"},
+		}, components["pkg:generic/acme-codec"].Properties)
+		assert.Equal(t, &[]cdx.Property{
+			{Name: "sbomsftw:vcpkg:baseline", Value: "3426db05b996481ca31e95fff3734cf23e0f51bc"},
+			{Name: "sbomsftw:vcpkg:registry", Value: "https://github.com/microsoft/vcpkg"},
+			{Name: "sbomsftw:vcpkg:minimumVersion", Value: "1.6.39"},
+		}, components["pkg:generic/libpng"].Properties)
+	})
+
+	t.Run("use builtin baseline without vcpkg configuration", func(t *testing.T) {
+		got, err := parseVcpkgManifest([]byte(`{"dependencies": ["zlib"], "builtin-baseline": "3426db05"}`), nil)
+		require.NoError(t, err)
+		assert.Equal(t, &[]cdx.Property{
+			{Name: "sbomsftw:vcpkg:baseline", Value: "3426db05"},
+			{Name: "sbomsftw:vcpkg:registry", Value: "builtin"},
+		}, componentsByPURL(t, got)["pkg:generic/zlib"].Properties)
+	})
+
+	t.Run("return an error for malformed package files", func(t *testing.T) {
+		_, err := parseConanLockfile([]byte(`{"version": "0.5"}`))
+		assert.Error(t, err)
+		_, err = parseConanfileTxt([]byte("[requires]\nzlib\n"))
+		assert.Error(t, err)
+		_, err = parseVcpkgManifest([]byte(`{"dependencies": "zlib"}`), nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("implement Stringer correctly", func(t *testing.T) {
+		assert.Equal(t, "cpp collector", CPP{}.String())
+	})
+}
@@ -0,0 +1,68 @@
+package collectors
+
+import (
+	"context"
+	"os"
+	fp "path/filepath"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	log "github.com/sirupsen/logrus"
+)
+
+type Dart struct {
+	executor shellExecutor
+}
+
+func NewDartCollector() Dart {
+	return Dart{
+		executor: defaultShellExecutor{},
+	}
+}
+
+// MatchLanguageFiles implements LanguageCollector interface.
+func (d Dart) MatchLanguageFiles(isDir bool, filepath string) bool {
+	if isDir {
+		return false
+	}
+	for _, dir := range strings.Split(fp.Dir(filepath), string(os.PathSeparator)) {
+		if dir == ".dart_tool" || dir == ".pub-cache" || dir == ".symlinks" { // Ignore package caches
+			return false
+		}
+	}
+	filename := fp.Base(filepath)
+
+	return filename == "pubspec.yaml" || filename == "pubspec.lock"
+}
+
+// BootstrapLanguageFiles implements LanguageCollector interface.
+func (d Dart) BootstrapLanguageFiles(_ context.Context, bomRoots []string) []string {
+	return SquashToDirs(bomRoots)
+}
+
+/*
+GenerateBOM implements LanguageCollector interface. pubspec.lock is parsed natively. Falls back
+to cdxgen when the lockfile is missing or can't be parsed.
+*/
+func (d Dart) GenerateBOM(ctx context.Context, bomRoot string) (*cdx.BOM, error) {
+	const language = "dart"
+
+	if contents, err := os.ReadFile(fp.Join(bomRoot, "pubspec.lock")); err == nil {
+		bom, err := parsePubspecLock(contents)
+		if err == nil {
+			return bom, nil
+		}
+		log.WithFields(log.Fields{
+			"collector":       d,
+			"collection path": bomRoot,
+			"error":           err,
+		}).Debug("can't parse pubspec.lock natively, falling back to cdxgen")
+	}
+
+	return d.executor.bomFromCdxgen(ctx, bomRoot, language, false)
+}
+
+// String implements LanguageCollector interface.
+func (d Dart) String() string {
+	return "dart collector"
+}
@@ -0,0 +1,133 @@
+package collectors
+
+import (
+	"context"
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vinted/sbomsftw/pkg/bomtools"
+)
+
+func TestDartCollector(t *testing.T) {
+	t.Run("match correct package files", func(t *testing.T) {
+		dartCollector := Dart{}
+		assert.True(t, dartCollector.MatchLanguageFiles(false, "pubspec.yaml"))
+		assert.True(t, dartCollector.MatchLanguageFiles(false, "/opt/app/pubspec.lock"))
+		assert.False(t, dartCollector.MatchLanguageFiles(false, "/opt/app/.dart_tool/pub/pubspec.lock"))
+		assert.False(t, dartCollector.MatchLanguageFiles(false, "/root/.pub-cache/hosted/pub.dev/http-1.1.0/pubspec.yaml"))
+		assert.False(t, dartCollector.MatchLanguageFiles(true, "pubspec.yaml"))
+		assert.False(t, dartCollector.MatchLanguageFiles(false, "/etc/passwd"))
+	})
+
+	t.Run("bootstrap language files correctly", func(t *testing.T) {
+		got := Dart{}.BootstrapLanguageFiles(context.Background(), []string{
+			"/tmp/some-random-dir/pubspec.yaml",
+			"/tmp/some-random-dir/pubspec.lock",
+			"/tmp/some-random-dir/packages/inner/pubspec.yaml",
+		})
+		assert.ElementsMatch(t, []string{"/tmp/some-random-dir", "/tmp/some-random-dir/packages/inner"}, got)
+	})
+
+	t.Run("fall back to cdxgen when pubspec.lock is missing", func(t *testing.T) {
+		const bomRoot = "/tmp/some-random-dir"
+		executor := new(mockShellExecutor)
+		executor.On("bomFromCdxgen", bomRoot, "dart", false).Return(new(cdx.BOM), nil)
+		_, _ = Dart{executor: executor}.GenerateBOM(context.Background(), bomRoot)
+		executor.AssertExpectations(t)
+	})
+
+	t.Run("generate BOM natively from pubspec.lock", func(t *testing.T) {
+		executor := new(mockShellExecutor)
+		got, err := Dart{executor: executor}.GenerateBOM(context.Background(), "../../integration/test/dart")
+		require.NoError(t, err)
+		executor.AssertNotCalled(t, "bomFromCdxgen")
+
+		assert.ElementsMatch(t, []string{
+			"pkg:pub/async@2.11.0",
+			"pkg:pub/flutter_lints@2.0.3",
+			"pkg:pub/http@1.1.0",
+			"pkg:pub/shared_widgets@1.0.0",
+			"pkg:pub/url_launcher@6.1.14",
+		}, purls(t, got))
+
+		components := componentsByPURL(t, got)
+		assert.Equal(t, cdx.ScopeRequired, components["pkg:pub/http@1.1.0"].Scope)
+		assert.Equal(t, cdx.ScopeRequired, components["pkg:pub/async@2.11.0"].Scope)
+		assert.Equal(t, cdx.ScopeOptional, components["pkg:pub/flutter_lints@2.0.3"].Scope)
+
+		http := components["pkg:pub/http@1.1.0"]
+		assert.Equal(t, &[]cdx.Hash{{
+			Algorithm: cdx.HashAlgoSHA256,
+			Value:     "759d1a329847dd0f39226c688d3e06a6b8679668e350e2891a6474f8b4bb8525",
+		}}, http.Hashes)
+		assert.Equal(t, &[]cdx.ExternalReference{
+			{Type: cdx.ERTypeDistribution, URL: "https://pub.dev/packages/http"},
+		}, http.ExternalReferences)
+		assert.Equal(t, &[]cdx.ExternalReference{
+			{Type: cdx.ERTypeVCS, URL: "https://github.com/flutter/packages.git#3f7d1b5a2c9e8d7f6a5b4c3d2e1f0a9b8c7d6e5f"},
+		}, components["pkg:pub/url_launcher@6.1.14"].ExternalReferences)
+		assert.Equal(t, &[]cdx.ExternalReference{
+			{Type: cdx.ERTypeOther, URL: "../shared_widgets"},
+		}, components["pkg:pub/shared_widgets@1.0.0"].ExternalReferences)
+
+		assert.Equal(t, &[]cdx.Property{
+			{Name: "sbomsftw:pub:sdk", Value: "dart >=3.1.0 <4.0.0"},
+			{Name: "sbomsftw:pub:sdk", Value: "flutter >=3.13.0"},
+		}, got.Properties)
+	})
+
+	t.Run("keep SDK constraints of every package when merging", func(t *testing.T) {
+		app, err := Dart{executor: new(mockShellExecutor)}.GenerateBOM(context.Background(), "../../integration/test/dart")
+		require.NoError(t, err)
+		server, err := parsePubspecLock([]byte(`
+packages:
+  http:
+    dependency: "direct main"
+    description:
+      name: http
+      sha256: "759d1a329847dd0f39226c688d3e06a6b8679668e350e2891a6474f8b4bb8525"
+      url: "https://pub.dev"
+    source: hosted
+    version: "1.1.0"
+  shelf:
+    dependency: "direct main"
+    description:
+      name: shelf
+      sha256: ad29c505aee705f41a4d8963641f91ac4cee3c8fad5947e033390a7bd8180fa4
+      url: "https://pub.dev"
+    source: hosted
+    version: "1.4.1"
+sdks:
+  dart: ">=3.2.0 <4.0.0"
+`))
+		require.NoError(t, err)
+
+		got, err := bomtools.MergeSBOMs(bomtools.MergeSBOMParam{SBOMs: []*cdx.BOM{app, server}})
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []string{
+			"pkg:pub/async@2.11.0",
+			"pkg:pub/flutter_lints@2.0.3",
+			"pkg:pub/http@1.1.0",
+			"pkg:pub/shared_widgets@1.0.0",
+			"pkg:pub/shelf@1.4.1",
+			"pkg:pub/url_launcher@6.1.14",
+		}, purls(t, got))
+		assert.ElementsMatch(t, []cdx.Property{
+			{Name: "sbomsftw:pub:sdk", Value: "dart >=3.1.0 <4.0.0"},
+			{Name: "sbomsftw:pub:sdk", Value: "flutter >=3.13.0"},
+			{Name: "sbomsftw:pub:sdk", Value: "dart >=3.2.0 <4.0.0"},
+		}, *got.Properties)
+	})
+
+	t.Run("return an error for malformed pubspec.lock", func(t *testing.T) {
+		_, err := parsePubspecLock([]byte(`packages: [async, http]`))
+		assert.Error(t, err)
+	})
+
+	t.Run("implement Stringer correctly", func(t *testing.T) {
+		assert.Equal(t, "dart collector", Dart{}.String())
+	})
+}
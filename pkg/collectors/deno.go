@@ -0,0 +1,138 @@
+package collectors
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+/*
+denoLockfile represents deno.lock files. Lockfile version 2 stores npm packages under npm.packages, version 3
+moves npm & jsr packages under packages, while versions 4 & newer store them at the top level.
+*/
+type denoLockfile struct {
+	Version  string `json:"version"`
+	Packages struct {
+		JSR map[string]denoPackage `json:"jsr"`
+		NPM map[string]denoPackage `json:"npm"`
+	} `json:"packages"` // Lockfile version 3
+	NPM    json.RawMessage        `json:"npm"`
+	JSR    map[string]denoPackage `json:"jsr"`
+	Remote map[string]string      `json:"remote"`
+}
+
+type denoPackage struct {
+	Integrity string `json:"integrity"`
+	Tarball   string `json:"tarball"`
+}
+
+/*
+npmPackages returns locked npm packages keyed by name@version. Lockfile version 2 nests those one level deeper,
+next to the specifiers.
+*/
+func (l denoLockfile) npmPackages() (map[string]denoPackage, error) {
+	if len(l.NPM) == 0 {
+		return l.Packages.NPM, nil
+	}
+
+	var packages map[string]denoPackage
+	if l.Version == "2" {
+		var npm struct {
+			Packages map[string]denoPackage `json:"packages"`
+		}
+		if err := json.Unmarshal(l.NPM, &npm); err != nil {
+			return nil, err
+		}
+		packages = npm.Packages
+	} else if err := json.Unmarshal(l.NPM, &packages); err != nil {
+		return nil, err
+	}
+
+	return packages, nil
+}
+
+/*
+denoRemoteComponent converts a remote module import into a pkg:generic component namespaced by its hostname.
+Deno locks every remote module by its SHA-256 checksum, which is recorded as the component hash. E.g.
+
+	https://deno.land/std@0.200.0/path/mod.ts
+
+is converted into: pkg:generic/deno.land/std%400.200.0/path/mod.ts
+*/
+func denoRemoteComponent(moduleURL, checksum string) (cdx.Component, bool) {
+	parsed, err := url.Parse(moduleURL)
+	if err != nil || parsed.Host == "" {
+		return cdx.Component{}, false
+	}
+
+	dir, name := path.Split(strings.TrimSuffix(parsed.Path, "/"))
+	if name == "" {
+		return cdx.Component{}, false
+	}
+
+	component := newLibraryComponent(packageURL("generic", parsed.Host+dir, name, ""), moduleURL, "")
+	component.Scope = cdx.ScopeRequired
+	addHashes(&component, cdx.Hash{Algorithm: cdx.HashAlgoSHA256, Value: checksum})
+	addExternalReference(&component, cdx.ERTypeDistribution, moduleURL)
+
+	return component, true
+}
+
+/*
+parseDenoLockfile converts deno.lock contents into components. npm packages become pkg:npm components, while
+jsr packages & remote module imports become pkg:generic components namespaced by their hostname. Keys of npm
+packages carry resolved peer dependencies after an underscore, those are dropped. E.g.
+
+	preact-render-to-string@6.2.2_preact@10.19.6
+*/
+func parseDenoLockfile(contents []byte) ([]cdx.Component, error) {
+	var lockfile denoLockfile
+	if err := json.Unmarshal(contents, &lockfile); err != nil {
+		return nil, fmt.Errorf("can't parse deno.lock: %w", err)
+	}
+	npmPackages, err := lockfile.npmPackages()
+	if err != nil {
+		return nil, fmt.Errorf("can't parse deno.lock npm packages: %w", err)
+	}
+	jsrPackages := lockfile.JSR
+	if jsrPackages == nil {
+		jsrPackages = lockfile.Packages.JSR
+	}
+
+	var components []cdx.Component
+	for key, p := range npmPackages {
+		name, version := splitYarnDescriptor(key)
+		version, _, _ = strings.Cut(version, "_")
+		if c, ok := npmComponent(name, version, p.Tarball, p.Integrity, false, false); ok {
+			components = append(components, c)
+		}
+	}
+
+	for key, p := range jsrPackages {
+		name, version := splitYarnDescriptor(key)
+		scope, packageName, ok := strings.Cut(name, "/")
+		if !ok {
+			continue
+		}
+
+		component := newLibraryComponent(packageURL("generic", "jsr.io/"+scope, packageName, version), name, version)
+		component.Scope = cdx.ScopeRequired
+		if p.Integrity != "" {
+			addHashes(&component, cdx.Hash{Algorithm: cdx.HashAlgoSHA256, Value: p.Integrity})
+		}
+		addExternalReference(&component, cdx.ERTypeDistribution, "https://jsr.io/"+name+"/"+version)
+		components = append(components, component)
+	}
+
+	for moduleURL, checksum := range lockfile.Remote {
+		if c, ok := denoRemoteComponent(moduleURL, checksum); ok {
+			components = append(components, c)
+		}
+	}
+
+	return uniqueComponents(components), nil
+}
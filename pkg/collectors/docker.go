@@ -0,0 +1,53 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"os"
+	fp "path/filepath"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+// Docker collects base images of Dockerfiles & Containerfiles.
+type Docker struct{}
+
+func NewDockerCollector() Docker {
+	return Docker{}
+}
+
+// MatchLanguageFiles implements LanguageCollector interface.
+func (d Docker) MatchLanguageFiles(isDir bool, filepath string) bool {
+	if isDir {
+		return false
+	}
+	for _, dir := range strings.Split(fp.Dir(filepath), string(os.PathSeparator)) {
+		if dir == "node_modules" || dir == "vendor" { // Ignore Dockerfiles shipped with dependencies
+			return false
+		}
+	}
+	filename := fp.Base(filepath)
+
+	return filename == "Dockerfile" || filename == "Containerfile" || strings.HasSuffix(filename, ".Dockerfile")
+}
+
+// BootstrapLanguageFiles implements LanguageCollector interface. Every Dockerfile is a separate bom root.
+func (d Docker) BootstrapLanguageFiles(_ context.Context, bomRoots []string) []string {
+	return bomRoots
+}
+
+// GenerateBOM implements LanguageCollector interface.
+func (d Docker) GenerateBOM(_ context.Context, bomRoot string) (*cdx.BOM, error) {
+	contents, err := os.ReadFile(bomRoot)
+	if err != nil {
+		return nil, fmt.Errorf("can't read %s: %w", bomRoot, err)
+	}
+
+	return parseDockerfile(contents)
+}
+
+// String implements LanguageCollector interface.
+func (d Docker) String() string {
+	return "docker collector"
+}
@@ -0,0 +1,127 @@
+package collectors
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vinted/sbomsftw/pkg/bomtools"
+)
+
+func TestDockerCollector(t *testing.T) {
+	t.Run("match correct package files", func(t *testing.T) {
+		dockerCollector := Docker{}
+		assert.True(t, dockerCollector.MatchLanguageFiles(false, "Dockerfile"))
+		assert.True(t, dockerCollector.MatchLanguageFiles(false, "/opt/build/api.Dockerfile"))
+		assert.True(t, dockerCollector.MatchLanguageFiles(false, "/opt/Containerfile"))
+		assert.False(t, dockerCollector.MatchLanguageFiles(false, "/opt/node_modules/sharp/Dockerfile"))
+		assert.False(t, dockerCollector.MatchLanguageFiles(false, "/opt/Dockerfile.dockerignore"))
+		assert.False(t, dockerCollector.MatchLanguageFiles(false, "/opt/docker-compose.yml"))
+		assert.False(t, dockerCollector.MatchLanguageFiles(true, "Dockerfile"))
+	})
+
+	t.Run("bootstrap language files correctly", func(t *testing.T) {
+		bomRoots := []string{"/tmp/some-random-dir/Dockerfile", "/tmp/some-random-dir/worker.Dockerfile"}
+		assert.Equal(t, bomRoots, Docker{}.BootstrapLanguageFiles(context.Background(), bomRoots))
+	})
+
+	t.Run("collect base images of multi-stage builds", func(t *testing.T) {
+		got, err := Docker{}.GenerateBOM(context.Background(), "../../integration/test/docker/Dockerfile")
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []string{
+			"pkg:docker/alpine@3.18",
+			"pkg:docker/golang@1.21",
+			"pkg:docker/node@18-alpine",
+		}, purls(t, got))
+
+		components := componentsByPURL(t, got)
+		assert.Equal(t, cdx.ScopeRequired, components["pkg:docker/alpine@3.18"].Scope)
+		assert.Equal(t, cdx.ScopeExcluded, components["pkg:docker/node@18-alpine"].Scope)
+		assert.Equal(t, cdx.ComponentTypeContainer, components["pkg:docker/node@18-alpine"].Type)
+		assert.Equal(t, &[]cdx.Property{
+			{Name: "sbomsftw:docker:platform", Value: "$BUILDPLATFORM"},
+		}, components["pkg:docker/node@18-alpine"].Properties)
+
+		golang := components["pkg:docker/golang@1.21"]
+		assert.Equal(t, cdx.ScopeExcluded, golang.Scope)
+		assert.Equal(t, &[]cdx.Hash{{
+			Algorithm: cdx.HashAlgoSHA256,
+			Value:     "4d5ec2ca0ba1a6b5c4e4b2c1a0f0a8d6e4c2b0a8f6e4d2c0b8a6f4e2d0c8b6a4",
+		}}, golang.Hashes)
+	})
+
+	t.Run("keep image tags as they are when merging", func(t *testing.T) {
+		build, err := Docker{}.GenerateBOM(context.Background(), "../../integration/test/docker/Dockerfile")
+		require.NoError(t, err)
+
+		dockerfile := filepath.Join(t.TempDir(), "Dockerfile")
+		require.NoError(t, os.WriteFile(dockerfile, []byte("FROM node:v18\nFROM alpine:3.18\n"), 0o644))
+		legacy, err := Docker{}.GenerateBOM(context.Background(), dockerfile)
+		require.NoError(t, err)
+
+		got, err := bomtools.MergeSBOMs(bomtools.MergeSBOMParam{SBOMs: []*cdx.BOM{build, legacy}})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{
+			"pkg:docker/alpine@3.18",
+			"pkg:docker/golang@1.21",
+			"pkg:docker/node@18-alpine",
+			"pkg:docker/node@v18",
+		}, purls(t, got))
+	})
+
+	t.Run("collect base images from other registries", func(t *testing.T) {
+		got, err := Docker{}.GenerateBOM(context.Background(), "../../integration/test/docker/worker.Dockerfile")
+		require.NoError(t, err)
+
+		components := componentsByPURL(t, got)
+		assert.Equal(t, []string{"pkg:docker/ghcr.io/acme/base-images/python@3.11-slim"}, purls(t, got))
+		python := components["pkg:docker/ghcr.io/acme/base-images/python@3.11-slim"]
+		assert.Equal(t, "ghcr.io/acme/base-images", python.Group)
+		assert.Equal(t, cdx.ScopeExcluded, python.Scope)
+	})
+
+	t.Run("parse image references correctly", func(t *testing.T) {
+		for reference, want := range map[string]string{
+			"node":                                 "pkg:docker/node@latest",
+			"docker.io/library/node:20":            "pkg:docker/node@20",
+			"bitnami/redis:7.2":                    "pkg:docker/bitnami/redis@7.2",
+			"localhost:5000/api:dev":               "pkg:docker/localhost:5000/api@dev",
+			"registry.acme.com/team/api@sha256:ab": "pkg:docker/registry.acme.com/team/api@sha256:ab",
+		} {
+			assert.Equal(t, want, parseDockerImageReference(reference).component().PackageURL, reference)
+		}
+	})
+
+	t.Run("expand Dockerfile variables correctly", func(t *testing.T) {
+		variables := map[string]string{"VERSION": "18", "EMPTY": ""}
+		for s, want := range map[string]string{
+			"node:$VERSION":          "node:18",
+			"node:${VERSION}-alpine": "node:18-alpine",
+			"node:${EMPTY:-20}":      "node:20",
+			"node${VERSION:+-slim}":  "node-slim",
+			"node${EMPTY:+-slim}":    "node",
+		} {
+			got, resolved := expandDockerfileVariables(s, variables)
+			assert.True(t, resolved, s)
+			assert.Equal(t, want, got, s)
+		}
+		_, resolved := expandDockerfileVariables("${REGISTRY}/api", variables)
+		assert.False(t, resolved)
+	})
+
+	t.Run("return an error for Dockerfiles without base images", func(t *testing.T) {
+		_, err := parseDockerfile([]byte("# FROM alpine\nRUN echo hello\n"))
+		assert.Error(t, err)
+		_, err = Docker{}.GenerateBOM(context.Background(), "/tmp/some-random-dir/Dockerfile")
+		assert.Error(t, err)
+	})
+
+	t.Run("implement Stringer correctly", func(t *testing.T) {
+		assert.Equal(t, "docker collector", Docker{}.String())
+	})
+}
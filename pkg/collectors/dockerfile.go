@@ -0,0 +1,243 @@
+package collectors
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	log "github.com/sirupsen/logrus"
+)
+
+// Dockerfile variable references. E.g. $NODE_VERSION, ${NODE_VERSION} or ${NODE_VERSION:-18}
+var dockerfileVariablePattern = regexp.MustCompile(`\$(?:\{([A-Za-z_][A-Za-z0-9_]*)(?:(:[-+])([^}]*))?\}|([A-Za-z_][A-Za-z0-9_]*))`)
+
+// dockerStage is a single build stage started by a FROM instruction.
+type dockerStage struct {
+	image, alias, platform string
+}
+
+/*
+expandDockerfileVariables substitutes variable references with the values given. False is returned
+when some of the referenced variables aren't declared.
+*/
+func expandDockerfileVariables(s string, variables map[string]string) (string, bool) {
+	resolved := true
+	expanded := dockerfileVariablePattern.ReplaceAllStringFunc(s, func(reference string) string {
+		m := dockerfileVariablePattern.FindStringSubmatch(reference)
+		name, modifier, word := m[1]+m[4], m[2], m[3]
+		value, declared := variables[name]
+
+		switch {
+		case modifier == ":-" && value == "":
+			return word
+		case modifier == ":+" && value != "":
+			return word
+		case modifier == ":+":
+			return ""
+		case !declared:
+			resolved = false
+		}
+		return value
+	})
+
+	return expanded, resolved
+}
+
+/*
+dockerfileInstructions splits Dockerfile contents into instructions. Comments are dropped & lines
+continued with a trailing backslash are joined.
+*/
+func dockerfileInstructions(contents []byte) ([]string, error) {
+	var (
+		instructions []string
+		current      strings.Builder
+	)
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasSuffix(line, "\\") {
+			current.WriteString(strings.TrimSuffix(line, "\\") + " ")
+			continue
+		}
+		current.WriteString(line)
+		if instruction := strings.TrimSpace(current.String()); instruction != "" {
+			instructions = append(instructions, instruction)
+		}
+		current.Reset()
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("can't parse Dockerfile: %w", err)
+	}
+
+	return instructions, nil
+}
+
+/*
+parseDockerfileStages extracts build stages from Dockerfile FROM instructions. E.g.
+
+	ARG NODE_VERSION=18
+	FROM --platform=linux/amd64 node:${NODE_VERSION}-alpine AS build
+
+Only ARG instructions preceding the first FROM can be used in FROM instructions, their default values
+are substituted. Images referencing arguments without default values can't be resolved & are skipped.
+*/
+func parseDockerfileStages(contents []byte) ([]dockerStage, error) {
+	instructions, err := dockerfileInstructions(contents)
+	if err != nil {
+		return nil, err
+	}
+
+	var stages []dockerStage
+	globalArgs := make(map[string]string)
+	for _, instruction := range instructions {
+		fields := strings.Fields(instruction)
+		switch keyword := strings.ToUpper(fields[0]); {
+		case keyword == "ARG" && len(stages) == 0:
+			for _, arg := range fields[1:] {
+				name, value, ok := strings.Cut(arg, "=")
+				if !ok {
+					continue // Arguments without a default value must be passed at build time
+				}
+				value, _ = expandDockerfileVariables(strings.Trim(value, `"'`), globalArgs)
+				globalArgs[name] = value
+			}
+		case keyword == "FROM":
+			var stage dockerStage
+			arguments := fields[1:]
+			for len(arguments) > 0 && strings.HasPrefix(arguments[0], "--") {
+				if platform, ok := strings.CutPrefix(arguments[0], "--platform="); ok {
+					stage.platform = platform // Automatic platform arguments, e.g. $BUILDPLATFORM, are kept as is
+					if expanded, resolved := expandDockerfileVariables(platform, globalArgs); resolved {
+						stage.platform = expanded
+					}
+				}
+				arguments = arguments[1:]
+			}
+			if len(arguments) == 0 {
+				return nil, fmt.Errorf("can't parse Dockerfile: malformed instruction %q", instruction)
+			}
+			if len(arguments) == 3 && strings.EqualFold(arguments[1], "AS") {
+				stage.alias = arguments[2]
+			}
+
+			image, resolved := expandDockerfileVariables(arguments[0], globalArgs)
+			if !resolved {
+				log.WithField("instruction", instruction).Debug("skipping base image with undeclared variables")
+				image = ""
+			}
+			stage.image = image
+			stages = append(stages, stage)
+		}
+	}
+
+	return stages, nil
+}
+
+// dockerImageReference is a parsed image reference. E.g. ghcr.io/acme/api:1.2.0@sha256:<digest>
+type dockerImageReference struct {
+	registry, repository, tag, digest string
+}
+
+func parseDockerImageReference(reference string) dockerImageReference {
+	var r dockerImageReference
+	reference, r.digest, _ = strings.Cut(reference, "@")
+	if i := strings.LastIndex(reference, ":"); i > strings.LastIndex(reference, "/") {
+		reference, r.tag = reference[:i], reference[i+1:]
+	}
+
+	first, rest, found := strings.Cut(reference, "/")
+	if found && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		r.registry, r.repository = first, rest
+	} else {
+		r.registry, r.repository = "docker.io", reference
+	}
+	r.repository = strings.TrimPrefix(r.repository, "library/")
+
+	return r
+}
+
+/*
+component converts the image reference into a container component with a pkg:docker Package URL.
+As described in the Package URL specification, images hosted in other registries than Docker Hub have
+the registry as part of the namespace. The version is the tag, or the digest for images pinned only by
+a digest. Digests are recorded as hashes as well.
+*/
+func (r dockerImageReference) component() cdx.Component {
+	namespace, name := "", r.repository
+	if i := strings.LastIndex(r.repository, "/"); i != -1 {
+		namespace, name = r.repository[:i], r.repository[i+1:]
+	}
+	if r.registry != "docker.io" {
+		namespace = strings.Trim(r.registry+"/"+namespace, "/")
+	}
+
+	version := r.tag
+	if version == "" {
+		version = r.digest
+	}
+	if version == "" {
+		version = "latest"
+	}
+
+	component := newLibraryComponent(packageURL("docker", namespace, name, version), name, version)
+	component.Type = cdx.ComponentTypeContainer
+	component.Group = namespace
+	if algorithm, digest, _ := strings.Cut(r.digest, ":"); algorithm == "sha256" {
+		addHashes(&component, cdx.Hash{Algorithm: cdx.HashAlgoSHA256, Value: digest})
+	}
+
+	return component
+}
+
+/*
+parseDockerfile converts base images of Dockerfile build stages into container components. Stages built
+on top of earlier stages & scratch images are skipped. Base images the final stage is built on are required,
+while base images of the other stages are needed only to build the image, so they are excluded.
+*/
+func parseDockerfile(contents []byte) (*cdx.BOM, error) {
+	stages, err := parseDockerfileStages(contents)
+	if err != nil {
+		return nil, err
+	}
+	if len(stages) == 0 {
+		return nil, fmt.Errorf("can't parse Dockerfile: no FROM instructions found")
+	}
+
+	aliases := make(map[string]int)
+	for i, s := range stages {
+		if s.alias != "" {
+			aliases[strings.ToLower(s.alias)] = i
+		}
+	}
+	// Follow the final stage through the stages it's built on
+	shipped := stages[len(stages)-1].image
+	for range stages {
+		i, ok := aliases[strings.ToLower(shipped)]
+		if !ok {
+			break
+		}
+		shipped = stages[i].image
+	}
+
+	var components []cdx.Component
+	for i, s := range stages {
+		if j, ok := aliases[strings.ToLower(s.image)]; (ok && j < i) || s.image == "" || strings.EqualFold(s.image, "scratch") {
+			continue
+		}
+		component := parseDockerImageReference(s.image).component()
+		component.Scope = cdx.ScopeExcluded
+		if s.image == shipped {
+			component.Scope = cdx.ScopeRequired
+		}
+		addProperty(&component, "docker:platform", s.platform)
+		components = append(components, component)
+	}
+
+	return bomFromComponents(uniqueComponents(components)), nil
+}
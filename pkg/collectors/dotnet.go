@@ -0,0 +1,166 @@
+package collectors
+
+import (
+	"context"
+	"os"
+	fp "path/filepath"
+	"sort"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	log "github.com/sirupsen/logrus"
+)
+
+// Supported files by this collector.
+const (
+	packagesLockJSON       = "packages.lock.json"
+	packagesConfigFile     = "packages.config"
+	directoryPackagesProps = "Directory.Packages.props"
+)
+
+type DotNet struct {
+	executor shellExecutor
+}
+
+func NewDotNetCollector() DotNet {
+	return DotNet{
+		executor: defaultShellExecutor{},
+	}
+}
+
+// MatchLanguageFiles implements LanguageCollector interface.
+func (d DotNet) MatchLanguageFiles(isDir bool, filepath string) bool {
+	if isDir {
+		return false
+	}
+	for _, dir := range strings.Split(fp.Dir(filepath), string(os.PathSeparator)) {
+		if dir == "bin" || dir == "obj" { // Ignore build outputs
+			return false
+		}
+	}
+
+	switch filename := fp.Base(filepath); {
+	case filename == packagesLockJSON, filename == packagesConfigFile, filename == directoryPackagesProps:
+		return true
+	default:
+		return fp.Ext(filename) == ".csproj" || fp.Ext(filename) == ".fsproj"
+	}
+}
+
+// BootstrapLanguageFiles implements LanguageCollector interface.
+func (d DotNet) BootstrapLanguageFiles(_ context.Context, bomRoots []string) []string {
+	return SquashToDirs(bomRoots)
+}
+
+/*
+centralPackageVersionsFile finds Directory.Packages.props that applies to projects in the directory given.
+Just like MSBuild, the nearest file is used when walking up the directory tree. Walking stops at the root of
+the git checkout.
+*/
+func centralPackageVersionsFile(dir string) (string, bool) {
+	for current := dir; ; current = fp.Dir(current) {
+		candidate := fp.Join(current, directoryPackagesProps)
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate, true
+		}
+		if _, err := os.Stat(fp.Join(current, ".git")); err == nil || current == fp.Dir(current) {
+			return "", false
+		}
+	}
+}
+
+/*
+GenerateBOM implements LanguageCollector interface. packages.lock.json files list every resolved package,
+so they are preferred. Otherwise, PackageReference items of project files & legacy packages.config files
+are parsed, resolving centrally managed versions from Directory.Packages.props. Falls back to cdxgen when
+none of the files can be parsed.
+*/
+func (d DotNet) GenerateBOM(ctx context.Context, bomRoot string) (*cdx.BOM, error) {
+	const language = "dotnet"
+
+	logParseError := func(file string, err error) {
+		log.WithFields(log.Fields{
+			"collector":       d,
+			"collection path": bomRoot,
+			"error":           err,
+		}).Debugf("can't parse %s natively", file)
+	}
+
+	if contents, err := os.ReadFile(fp.Join(bomRoot, packagesLockJSON)); err == nil {
+		components, err := parseNuGetLockfile(contents)
+		if err == nil {
+			return bomFromComponents(components), nil
+		}
+		logParseError(packagesLockJSON, err)
+	}
+
+	var components []cdx.Component
+	parsed := false
+
+	centralVersions := make(map[string]string)
+	if props, found := centralPackageVersionsFile(bomRoot); found {
+		if contents, err := os.ReadFile(props); err == nil {
+			versions, global, err := parseCentralPackageVersions(contents)
+			if err != nil {
+				logParseError(props, err)
+			} else {
+				centralVersions = versions
+				components = append(components, global...)
+				parsed = parsed || fp.Dir(props) == bomRoot
+			}
+		}
+	}
+
+	entries, _ := os.ReadDir(bomRoot)
+	files := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if !e.IsDir() {
+			files = append(files, e.Name())
+		}
+	}
+	sort.Strings(files)
+
+	for _, f := range files {
+		var (
+			fileComponents []cdx.Component
+			err            error
+		)
+		switch {
+		case f == packagesConfigFile:
+			fileComponents, err = readAndParse(fp.Join(bomRoot, f), parsePackagesConfig)
+		case fp.Ext(f) == ".csproj" || fp.Ext(f) == ".fsproj":
+			fileComponents, err = readAndParse(fp.Join(bomRoot, f), func(contents []byte) ([]cdx.Component, error) {
+				return parseMSBuildProject(contents, centralVersions)
+			})
+		default:
+			continue
+		}
+		if err != nil {
+			logParseError(f, err)
+			continue
+		}
+		components = append(components, fileComponents...)
+		parsed = true
+	}
+
+	if parsed {
+		return bomFromComponents(mergeNuGetComponents(components)), nil
+	}
+
+	return d.executor.bomFromCdxgen(ctx, bomRoot, language, false)
+}
+
+// readAndParse reads the file given & parses its contents.
+func readAndParse(path string, parse func([]byte) ([]cdx.Component, error)) ([]cdx.Component, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return parse(contents)
+}
+
+// String implements LanguageCollector interface.
+func (d DotNet) String() string {
+	return "dotnet collector"
+}
@@ -0,0 +1,139 @@
+package collectors
+
+import (
+	"context"
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDotNetCollector(t *testing.T) {
+	t.Run("match correct package files", func(t *testing.T) {
+		dotnetCollector := DotNet{}
+		for _, f := range []string{
+			"packages.lock.json",
+			"/opt/src/Api/Api.csproj",
+			"/opt/src/Core/Core.fsproj",
+			"/opt/Directory.Packages.props",
+			"/opt/legacy/packages.config",
+		} {
+			assert.True(t, dotnetCollector.MatchLanguageFiles(false, f))
+		}
+		assert.False(t, dotnetCollector.MatchLanguageFiles(false, "/opt/src/Api/obj/project.assets.json"))
+		assert.False(t, dotnetCollector.MatchLanguageFiles(false, "/opt/src/Api/bin/Debug/packages.lock.json"))
+		assert.False(t, dotnetCollector.MatchLanguageFiles(true, "Api.csproj"))
+		assert.False(t, dotnetCollector.MatchLanguageFiles(false, "/opt/Directory.Build.props"))
+	})
+
+	t.Run("bootstrap language files correctly", func(t *testing.T) {
+		got := DotNet{}.BootstrapLanguageFiles(context.Background(), []string{
+			"/tmp/some-random-dir/Directory.Packages.props",
+			"/tmp/some-random-dir/src/Api/Api.csproj",
+			"/tmp/some-random-dir/src/Api/packages.lock.json",
+		})
+		assert.ElementsMatch(t, []string{"/tmp/some-random-dir", "/tmp/some-random-dir/src/Api"}, got)
+	})
+
+	t.Run("fall back to cdxgen when no package files can be parsed", func(t *testing.T) {
+		const bomRoot = "/tmp/some-random-dir"
+		executor := new(mockShellExecutor)
+		executor.On("bomFromCdxgen", bomRoot, "dotnet", false).Return(new(cdx.BOM), nil)
+		_, _ = DotNet{executor: executor}.GenerateBOM(context.Background(), bomRoot)
+		executor.AssertExpectations(t)
+	})
+
+	t.Run("generate BOM natively from packages.lock.json", func(t *testing.T) {
+		executor := new(mockShellExecutor)
+		got, err := DotNet{executor: executor}.GenerateBOM(context.Background(), "../../integration/test/dotnet/locked")
+		require.NoError(t, err)
+		executor.AssertNotCalled(t, "bomFromCdxgen")
+
+		assert.ElementsMatch(t, []string{
+			"pkg:nuget/Newtonsoft.Json@13.0.3",
+			"pkg:nuget/System.Text.Encodings.Web@7.0.0",
+		}, purls(t, got))
+
+		newtonsoft := componentsByPURL(t, got)["pkg:nuget/Newtonsoft.Json@13.0.3"]
+		assert.Equal(t, &[]cdx.Property{
+			{Name: "sbomsftw:nuget:targetFramework", Value: "net6.0"},
+			{Name: "sbomsftw:nuget:targetFramework", Value: "net7.0"},
+		}, newtonsoft.Properties)
+		require.NotNil(t, newtonsoft.Hashes)
+		assert.Equal(t, cdx.HashAlgoSHA512, (*newtonsoft.Hashes)[0].Algorithm)
+	})
+
+	t.Run("generate BOM natively from project files with central package management", func(t *testing.T) {
+		executor := new(mockShellExecutor)
+		got, err := DotNet{executor: executor}.GenerateBOM(context.Background(), "../../integration/test/dotnet/cpm/src/Api")
+		require.NoError(t, err)
+		executor.AssertNotCalled(t, "bomFromCdxgen")
+
+		assert.ElementsMatch(t, []string{
+			"pkg:nuget/FluentValidation",
+			"pkg:nuget/Microsoft.Extensions.Http@7.0.1",
+			"pkg:nuget/Nerdbank.GitVersioning@3.6.133",
+			"pkg:nuget/Polly@7.2.4",
+			"pkg:nuget/Serilog@3.0.1",
+			"pkg:nuget/StyleCop.Analyzers@1.1.118",
+			"pkg:nuget/System.Text.Json@6.0.8",
+		}, purls(t, got))
+
+		components := componentsByPURL(t, got)
+		assert.Equal(t, cdx.ScopeRequired, components["pkg:nuget/Serilog@3.0.1"].Scope)
+		assert.Equal(t, cdx.ScopeOptional, components["pkg:nuget/StyleCop.Analyzers@1.1.118"].Scope)
+		assert.Equal(t, cdx.ScopeOptional, components["pkg:nuget/Nerdbank.GitVersioning@3.6.133"].Scope)
+		assert.Equal(t, &[]cdx.Property{
+			{Name: "sbomsftw:nuget:targetFramework", Value: "net6.0"},
+		}, components["pkg:nuget/System.Text.Json@6.0.8"].Properties)
+		assert.Equal(t, &[]cdx.Property{
+			{Name: "sbomsftw:nuget:targetFramework", Value: "net6.0"},
+			{Name: "sbomsftw:nuget:targetFramework", Value: "net7.0"},
+		}, components["pkg:nuget/Polly@7.2.4"].Properties)
+	})
+
+	t.Run("generate BOM natively from packages.config", func(t *testing.T) {
+		executor := new(mockShellExecutor)
+		got, err := DotNet{executor: executor}.GenerateBOM(context.Background(), "../../integration/test/dotnet/legacy")
+		require.NoError(t, err)
+		executor.AssertNotCalled(t, "bomFromCdxgen")
+
+		components := componentsByPURL(t, got)
+		assert.ElementsMatch(t, []string{
+			"pkg:nuget/EntityFramework@6.4.4",
+			"pkg:nuget/Microsoft.CodeAnalysis.NetAnalyzers@7.0.3",
+			"pkg:nuget/log4net@2.0.15",
+		}, purls(t, got))
+		assert.Equal(t, cdx.ScopeOptional, components["pkg:nuget/Microsoft.CodeAnalysis.NetAnalyzers@7.0.3"].Scope)
+		assert.Equal(t, &[]cdx.Property{
+			{Name: "sbomsftw:nuget:targetFramework", Value: "net472"},
+		}, components["pkg:nuget/log4net@2.0.15"].Properties)
+	})
+
+	t.Run("pin NuGet versions correctly", func(t *testing.T) {
+		for version, want := range map[string]string{
+			"13.0.3":            "13.0.3",
+			"[13.0.3]":          "13.0.3",
+			"[13.0.3, )":        "",
+			"[1.0,2.0)":         "",
+			"6.*":               "",
+			"$(SerilogVersion)": "",
+		} {
+			assert.Equal(t, want, nugetVersion(version), version)
+		}
+	})
+
+	t.Run("return an error for malformed package files", func(t *testing.T) {
+		_, err := parseNuGetLockfile([]byte("{"))
+		assert.Error(t, err)
+		_, err = parseMSBuildProject([]byte("<Project><ItemGroup>"), nil)
+		assert.Error(t, err)
+		_, err = parsePackagesConfig([]byte("<packages>"))
+		assert.Error(t, err)
+	})
+
+	t.Run("implement Stringer correctly", func(t *testing.T) {
+		assert.Equal(t, "dotnet collector", DotNet{}.String())
+	})
+}
@@ -0,0 +1,61 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"os"
+	fp "path/filepath"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+// GitHubActions collects actions, reusable workflows & Docker images used by GitHub Actions.
+type GitHubActions struct{}
+
+func NewGitHubActionsCollector() GitHubActions {
+	return GitHubActions{}
+}
+
+// MatchLanguageFiles implements LanguageCollector interface. Matches workflows & action metadata files.
+func (g GitHubActions) MatchLanguageFiles(isDir bool, filepath string) bool {
+	if isDir {
+		return false
+	}
+	dirs := strings.Split(fp.Dir(filepath), string(os.PathSeparator))
+	for _, d := range dirs {
+		if d == "node_modules" { // Ignore actions shipped with dependencies
+			return false
+		}
+	}
+
+	filename, extension := fp.Base(filepath), fp.Ext(filepath)
+	if extension != ".yml" && extension != ".yaml" {
+		return false
+	}
+	if filename == "action.yml" || filename == "action.yaml" {
+		return true
+	}
+
+	return len(dirs) >= 2 && dirs[len(dirs)-2] == ".github" && dirs[len(dirs)-1] == "workflows"
+}
+
+// BootstrapLanguageFiles implements LanguageCollector interface. Every workflow is a separate bom root.
+func (g GitHubActions) BootstrapLanguageFiles(_ context.Context, bomRoots []string) []string {
+	return bomRoots
+}
+
+// GenerateBOM implements LanguageCollector interface.
+func (g GitHubActions) GenerateBOM(_ context.Context, bomRoot string) (*cdx.BOM, error) {
+	contents, err := os.ReadFile(bomRoot)
+	if err != nil {
+		return nil, fmt.Errorf("can't read %s: %w", bomRoot, err)
+	}
+
+	return parseWorkflow(contents)
+}
+
+// String implements LanguageCollector interface.
+func (g GitHubActions) String() string {
+	return "github actions collector"
+}
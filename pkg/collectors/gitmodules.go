@@ -0,0 +1,129 @@
+package collectors
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+)
+
+/*
+ResolveSubmoduleURL resolves submodule URLs relative to the URL of the repository declaring them, the same way
+git does. E.g. given https://github.com/acme/app.git & ../lib.git this function will return
+https://github.com/acme/lib.git. Absolute URLs are returned as is, while false is returned for relative URLs
+when the parent URL is unknown or malformed.
+*/
+func ResolveSubmoduleURL(parentURL, submoduleURL string) (string, bool) {
+	if !strings.HasPrefix(submoduleURL, "./") && !strings.HasPrefix(submoduleURL, "../") {
+		return submoduleURL, true
+	}
+	if parentURL == "" {
+		return "", false
+	}
+	endpoint, err := transport.NewEndpoint(parentURL)
+	if err != nil {
+		return "", false
+	}
+	endpoint.Path = path.Join(endpoint.Path, submoduleURL)
+
+	return endpoint.String(), true
+}
+
+/*
+gitRemoteAddress splits a git remote URL into its hostname & repository path. Both URLs & scp-like
+addresses are supported. E.g. given git@github.com:acme/lib.git this function will return
+github.com & acme/lib.
+*/
+func gitRemoteAddress(remoteURL string) (host, repositoryPath string, ok bool) {
+	if strings.Contains(remoteURL, "://") {
+		u, err := url.Parse(remoteURL)
+		if err != nil {
+			return "", "", false
+		}
+		host, repositoryPath = u.Hostname(), u.Path
+	} else {
+		address, p, found := strings.Cut(remoteURL, ":")
+		if !found || strings.Contains(address, "/") {
+			return "", "", false
+		}
+		_, host, _ = strings.Cut(address, "@")
+		if host == "" {
+			host = address
+		}
+		repositoryPath = p
+	}
+	repositoryPath = strings.TrimSuffix(strings.Trim(repositoryPath, "/"), ".git")
+
+	return host, repositoryPath, host != "" && repositoryPath != ""
+}
+
+/*
+gitComponent converts a git repository into a component pinned to the commit given. Repositories hosted on
+GitHub & Bitbucket become pkg:github & pkg:bitbucket components, others become pkg:generic components
+namespaced by their hostname. The remote URL is recorded as a VCS reference. Name is used only when the
+remote URL can't be parsed.
+*/
+func gitComponent(remoteURL, name, commit string) cdx.Component {
+	purlType, namespace := "generic", ""
+	if host, repositoryPath, ok := gitRemoteAddress(remoteURL); ok {
+		segments := strings.Split(repositoryPath, "/")
+		namespace, name = strings.Join(segments[:len(segments)-1], "/"), segments[len(segments)-1]
+		switch host {
+		case "github.com":
+			purlType = "github"
+		case "bitbucket.org":
+			purlType = "bitbucket"
+		default:
+			namespace = strings.Trim(host+"/"+namespace, "/")
+		}
+	}
+
+	component := newLibraryComponent(packageURL(purlType, namespace, name, commit), name, commit)
+	if purlType != "generic" {
+		component.Group = namespace
+	}
+	component.Scope = cdx.ScopeRequired
+	if remoteURL != "" && commit != "" {
+		remoteURL += "#" + commit
+	}
+	addExternalReference(&component, cdx.ERTypeVCS, remoteURL)
+
+	return component
+}
+
+// submoduleComponent converts a submodule into a component pinned to the commit recorded in the superproject.
+func submoduleComponent(submodule *config.Submodule, remoteURL, commit string) cdx.Component {
+	component := gitComponent(remoteURL, path.Base(submodule.Path), commit)
+	addProperty(&component, "git:path", submodule.Path)
+	addProperty(&component, "git:branch", submodule.Branch)
+
+	return component
+}
+
+/*
+parseGitmodules converts submodules declared in a .gitmodules file into components. commits maps submodule
+paths to commits they're pinned to, superprojectURL is used to resolve relative submodule URLs. Either of
+them can be empty, e.g. when collecting from a directory that isn't a git checkout.
+*/
+func parseGitmodules(contents []byte, commits map[string]string, superprojectURL string) (*cdx.BOM, error) {
+	modules := config.NewModules()
+	if err := modules.Unmarshal(contents); err != nil {
+		return nil, fmt.Errorf("can't parse .gitmodules: %w", err)
+	}
+
+	components := make([]cdx.Component, 0, len(modules.Submodules))
+	for _, s := range modules.Submodules {
+		if s.Path == "" {
+			continue
+		}
+		s.Path = path.Clean(s.Path)
+		remoteURL, _ := ResolveSubmoduleURL(superprojectURL, s.URL)
+		components = append(components, submoduleComponent(s, remoteURL, commits[s.Path]))
+	}
+
+	return bomFromComponents(uniqueComponents(components)), nil
+}
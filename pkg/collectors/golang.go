@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	cdx "github.com/CycloneDX/cyclonedx-go"
+	log "github.com/sirupsen/logrus"
 )
 
 type Golang struct {
@@ -23,36 +24,64 @@ func NewGolangCollector() Golang {
 func (g Golang) MatchLanguageFiles(isDir bool, filepath string) bool {
 	// Supported files by this collector
 	const (
-		goMod = "go.mod"
-		goSum = "go.sum"
-		goPkg = "Gopkg.lock"
+		goMod      = "go.mod"
+		goSum      = "go.sum"
+		goPkg      = "Gopkg.lock"
+		modulesTxt = "modules.txt"
 	)
 
-	for _, p := range strings.Split(fp.Dir(filepath), string(os.PathSeparator)) {
-		if p == "vendor" {
-			return false
-		}
-	}
-
 	if isDir {
 		return false
 	}
 
+	dirs := strings.Split(fp.Dir(filepath), string(os.PathSeparator))
 	filename := fp.Base(filepath)
+	for i, p := range dirs {
+		if p != "vendor" {
+			continue
+		}
+		// Sources of vendored modules are skipped, yet vendor/modules.txt lists what gets compiled
+		return i == len(dirs)-1 && filename == modulesTxt
+	}
 
 	return filename == goMod || filename == goSum || filename == goPkg
 }
 
-// GenerateBOM implements LanguageCollector interface
+/*
+GenerateBOM implements LanguageCollector interface. go.mod, go.sum & vendor/modules.txt files are parsed natively,
+without requiring network access or a Go toolchain. Falls back to cdxgen when neither go.mod nor vendor/modules.txt
+can be parsed, e.g. for legacy projects that only have Gopkg.lock.
+*/
 func (g Golang) GenerateBOM(ctx context.Context, bomRoot string) (*cdx.BOM, error) {
 	const language = "golang"
 
+	bom, err := bomFromGoMod(bomRoot)
+	if err == nil {
+		return bom, nil
+	}
+	log.WithFields(log.Fields{
+		"collector":       g,
+		"collection path": bomRoot,
+		"error":           err,
+	}).Debug("can't parse go.mod natively, falling back to cdxgen")
+
 	return g.executor.bomFromCdxgen(ctx, bomRoot, language, false)
 }
 
-// BootstrapLanguageFiles implements LanguageCollector interface.
+/*
+BootstrapLanguageFiles implements LanguageCollector interface. vendor/modules.txt files are collected
+together with go.mod files of their parent directory.
+*/
 func (g Golang) BootstrapLanguageFiles(_ context.Context, bomRoots []string) []string {
-	return SquashToDirs(bomRoots)
+	files := make([]string, 0, len(bomRoots))
+	for _, r := range bomRoots {
+		if fp.Base(r) == "modules.txt" && fp.Base(fp.Dir(r)) == "vendor" {
+			r = fp.Join(fp.Dir(fp.Dir(r)), "go.mod")
+		}
+		files = append(files, r)
+	}
+
+	return SquashToDirs(files)
 }
 
 func (g Golang) String() string {
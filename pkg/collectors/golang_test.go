@@ -6,6 +6,8 @@ import (
 
 	cdx "github.com/CycloneDX/cyclonedx-go"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vinted/sbomsftw/pkg/bomtools"
 )
 
 func TestGolangCollector(t *testing.T) {
@@ -33,6 +35,19 @@ func TestGolangCollector(t *testing.T) {
 		assert.False(t, golangCollector.MatchLanguageFiles(false, "/tmp/test-repo/vendor/go.sum"))
 		assert.False(t, golangCollector.MatchLanguageFiles(false, "/tmp/test-repo/inner-dir/vendor/go.mod"))
 		assert.False(t, golangCollector.MatchLanguageFiles(true, "/tmp/test-repo/vendor"))
+		assert.True(t, golangCollector.MatchLanguageFiles(false, "vendor/modules.txt"))
+		assert.True(t, golangCollector.MatchLanguageFiles(false, "/tmp/test-repo/inner-dir/vendor/modules.txt"))
+		assert.False(t, golangCollector.MatchLanguageFiles(false, "/tmp/test-repo/modules.txt"))
+		assert.False(t, golangCollector.MatchLanguageFiles(false, "/tmp/test-repo/vendor/github.com/acme/lib/vendor/modules.txt"))
+	})
+
+	t.Run("bootstrap vendor/modules.txt together with go.mod", func(t *testing.T) {
+		got := Golang{}.BootstrapLanguageFiles(context.Background(), []string{
+			"/tmp/some-random-dir/go.mod",
+			"/tmp/some-random-dir/vendor/modules.txt",
+			"/tmp/some-random-dir/legacy/vendor/modules.txt",
+		})
+		assert.ElementsMatch(t, []string{"/tmp/some-random-dir", "/tmp/some-random-dir/legacy"}, got)
 	})
 
 	t.Run("implement Stringer correctly", func(t *testing.T) {
@@ -46,4 +61,131 @@ func TestGolangCollector(t *testing.T) {
 		_, _ = Golang{executor: executor}.GenerateBOM(context.Background(), bomRoot)
 		executor.AssertExpectations(t)
 	})
+	t.Run("generate BOM natively from go.mod and go.sum", func(t *testing.T) {
+		executor := new(mockShellExecutor)
+		got, err := Golang{executor: executor}.GenerateBOM(context.Background(), "../../integration/test/golang")
+		require.NoError(t, err)
+		executor.AssertNotCalled(t, "bomFromCdxgen")
+
+		require.NotNil(t, got.Components)
+		properties := make(map[string]map[string]string)
+		for _, c := range *got.Components {
+			assert.Equal(t, cdx.ComponentTypeLibrary, c.Type)
+			properties[c.PackageURL] = make(map[string]string)
+			for _, p := range *c.Properties {
+				properties[c.PackageURL][p.Name] = p.Value
+			}
+		}
+
+		assert.Equal(t, map[string]map[string]string{
+			"pkg:golang/github.com/inconshreveable/mousetrap@v1.1.0": {
+				"sbomsftw:golang:indirect": "true",
+				"sbomsftw:golang:checksum": "h1:wN+x4NVGpMsO7ErUn/mUI3vEoE6Jt13X2s0bqwp9tc8=",
+			},
+			"pkg:golang/github.com/sirupsen/logrus@v1.9.3": {
+				"sbomsftw:golang:indirect": "false",
+				"sbomsftw:golang:checksum": "h1:dueUQJ1C2q9oE3F7wvmSGAaVtTmUizReu6fjN8uqzbQ=",
+			},
+			"pkg:golang/github.com/spf13/cobra@v1.8.0": {
+				"sbomsftw:golang:indirect": "false",
+				"sbomsftw:golang:checksum": "h1:7aJaZx1B85qltLMc546zn58BxxfZdR/W22ej9CFoEf0=",
+			},
+			"pkg:golang/github.com/spf13/pflag@v1.0.5": {
+				"sbomsftw:golang:indirect": "true",
+			},
+			"pkg:golang/github.com/vinted/internal-lib@v0.3.1": {
+				"sbomsftw:golang:indirect": "false",
+				"sbomsftw:golang:replace":  "github.com/vinted/internal-lib@v0.3.1 => ../internal-lib",
+			},
+			"pkg:golang/golang.org/x/sys@v0.15.0": {
+				"sbomsftw:golang:indirect": "true",
+				"sbomsftw:golang:replace":  "golang.org/x/sys@v0.0.0-20220715151400-c0bba94af5f8 => golang.org/x/sys@v0.15.0",
+				"sbomsftw:golang:checksum": "h1:h48lPFYpsTvQJZF4EKyI4aLHaev3CxivZmv7yZig9pc=",
+			},
+		}, properties)
+
+		require.NotNil(t, got.Metadata)
+		assert.Equal(t, "github.com/vinted/sample-service", got.Metadata.Component.Name)
+		assert.Equal(t, &[]cdx.Property{
+			{Name: "sbomsftw:golang:retract", Value: "v1.0.1"},
+			{Name: "sbomsftw:golang:retract", Value: "[v1.1.0, v1.1.5]"},
+		}, got.Properties)
+
+		merged, err := bomtools.MergeSBOMs(bomtools.MergeSBOMParam{SBOMs: []*cdx.BOM{got}})
+		require.NoError(t, err)
+		assert.Equal(t, got.Properties, merged.Properties)
+	})
+
+	t.Run("flag vendored modules from vendor/modules.txt", func(t *testing.T) {
+		executor := new(mockShellExecutor)
+		got, err := Golang{executor: executor}.GenerateBOM(context.Background(), "../../integration/test/golang/vendored")
+		require.NoError(t, err)
+		executor.AssertNotCalled(t, "bomFromCdxgen")
+
+		properties := make(map[string]map[string]string)
+		for _, c := range *got.Components {
+			properties[c.PackageURL] = make(map[string]string)
+			for _, p := range *c.Properties {
+				properties[c.PackageURL][p.Name] = p.Value
+			}
+		}
+
+		assert.Equal(t, map[string]map[string]string{
+			"pkg:golang/github.com/kr/text@v0.2.0": {
+				"sbomsftw:golang:indirect": "true",
+				"sbomsftw:golang:vendored": "false",
+			},
+			"pkg:golang/github.com/pkg/errors@v0.9.1": {
+				"sbomsftw:golang:indirect": "false",
+				"sbomsftw:golang:vendored": "true",
+			},
+			"pkg:golang/github.com/stretchr/objx@v0.5.0": {
+				"sbomsftw:golang:vendored": "true",
+				"sbomsftw:golang:explicit": "false",
+			},
+			"pkg:golang/golang.org/x/sys@v0.15.0": {
+				"sbomsftw:golang:indirect": "false",
+				"sbomsftw:golang:replace":  "golang.org/x/sys@v0.0.0-20220715151400-c0bba94af5f8 => golang.org/x/sys@v0.15.0",
+				"sbomsftw:golang:vendored": "true",
+			},
+		}, properties)
+		assert.Equal(t, "github.com/vinted/vendored-service", got.Metadata.Component.Name)
+	})
+
+	t.Run("parse vendor/modules.txt", func(t *testing.T) {
+		got := parseVendorModules([]byte(`# github.com/pkg/errors v0.9.1
+## explicit
+github.com/pkg/errors
+# example.com/old v1.0.0 => example.com/new v1.2.0
+example.com/new/pkg
+# example.com/local v0.1.0 => ./local
+## explicit; go 1.21
+example.com/local
+# example.com/wildcard => example.com/fork v0.2.0
+`))
+
+		require.Len(t, got, 3)
+		assert.Equal(t, "pkg:golang/github.com/pkg/errors@v0.9.1", got[0].PackageURL)
+		assert.Equal(t, &[]cdx.Property{
+			{Name: "sbomsftw:golang:vendored", Value: "true"},
+			{Name: "sbomsftw:golang:explicit", Value: "true"},
+		}, got[0].Properties)
+		assert.Equal(t, "pkg:golang/example.com/new@v1.2.0", got[1].PackageURL)
+		assert.Equal(t, &[]cdx.Property{
+			{Name: "sbomsftw:golang:vendored", Value: "true"},
+			{Name: "sbomsftw:golang:replace", Value: "example.com/old@v1.0.0 => example.com/new@v1.2.0"},
+			{Name: "sbomsftw:golang:explicit", Value: "false"},
+		}, got[1].Properties)
+		assert.Equal(t, "pkg:golang/example.com/local@v0.1.0", got[2].PackageURL)
+		assert.Equal(t, &[]cdx.Property{
+			{Name: "sbomsftw:golang:vendored", Value: "true"},
+			{Name: "sbomsftw:golang:replace", Value: "example.com/local@v0.1.0 => ./local"},
+			{Name: "sbomsftw:golang:explicit", Value: "true"},
+		}, got[2].Properties)
+	})
+
+	t.Run("return an error for malformed go.mod", func(t *testing.T) {
+		_, err := parseGoMod([]byte("module"), nil)
+		assert.Error(t, err)
+	})
 }
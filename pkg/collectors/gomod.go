@@ -0,0 +1,265 @@
+package collectors
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	fp "path/filepath"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+)
+
+/*
+goSumChecksums parses go.sum contents and returns a mapping of module@version to its h1: checksum.
+Lines describing only the go.mod file of a module (module version/go.mod h1:...) are skipped.
+*/
+func goSumChecksums(goSum []byte) map[string]string {
+	checksums := make(map[string]string)
+
+	scanner := bufio.NewScanner(bytes.NewReader(goSum))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 3 || strings.HasSuffix(fields[1], "/go.mod") {
+			continue
+		}
+		checksums[fields[0]+"@"+fields[1]] = fields[2]
+	}
+
+	return checksums
+}
+
+// golangPURL formats a pkg:golang Package URL from a module path & version.
+func golangPURL(modulePath, version string) string {
+	namespace, name := "", modulePath
+	if i := strings.LastIndex(modulePath, "/"); i != -1 {
+		namespace, name = modulePath[:i], modulePath[i+1:]
+	}
+
+	return packageURL("golang", namespace, name, version)
+}
+
+/*
+parseGoMod converts go.mod contents into pkg:golang components. Every required module is marked as either
+a direct or an indirect dependency. Replace directives are applied to the required modules, excluded module
+versions are dropped & retracted versions of the main module are recorded as BOM properties.
+When goSum is not nil, every module is cross-checked against it & its h1: checksum is recorded as a property.
+*/
+func parseGoMod(goMod, goSum []byte) (*cdx.BOM, error) {
+	modFile, err := modfile.Parse("go.mod", goMod, nil)
+	if err != nil {
+		return nil, fmt.Errorf("can't parse go.mod: %w", err)
+	}
+
+	excluded := make(map[module.Version]bool)
+	for _, e := range modFile.Exclude {
+		excluded[e.Mod] = true
+	}
+
+	// Version specific replacements take precedence over replacements that apply to every version
+	replacementFor := func(m module.Version) (module.Version, bool) {
+		var wildcard *modfile.Replace
+		for _, r := range modFile.Replace {
+			if r.Old.Path != m.Path {
+				continue
+			}
+			if r.Old.Version == m.Version {
+				return r.New, true
+			}
+			if r.Old.Version == "" {
+				wildcard = r
+			}
+		}
+		if wildcard != nil {
+			return wildcard.New, true
+		}
+
+		return module.Version{}, false
+	}
+
+	var checksums map[string]string
+	if goSum != nil {
+		checksums = goSumChecksums(goSum)
+	}
+
+	components := make([]cdx.Component, 0, len(modFile.Require))
+	for _, r := range modFile.Require {
+		if excluded[r.Mod] {
+			log.WithField("module", r.Mod.String()).Debug("skipping module excluded in go.mod")
+			continue
+		}
+
+		resolved, replaces := r.Mod, ""
+		if replacement, ok := replacementFor(r.Mod); ok {
+			if replacement.Version != "" {
+				resolved = replacement
+			}
+			// Local filesystem replacements keep the original module identity
+			replaces = r.Mod.String() + " => " + replacement.String()
+		}
+
+		purl := golangPURL(resolved.Path, resolved.Version)
+		component := newLibraryComponent(purl, resolved.Path, resolved.Version)
+		addProperty(&component, "golang:indirect", fmt.Sprintf("%t", r.Indirect))
+		addProperty(&component, "golang:replace", replaces)
+
+		if checksums != nil {
+			checksum, ok := checksums[resolved.String()]
+			if !ok && resolved.Version != "" {
+				log.WithField("module", resolved.String()).Debug("module is missing from go.sum")
+			}
+			addProperty(&component, "golang:checksum", checksum)
+		}
+		components = append(components, component)
+	}
+
+	bom := bomFromComponents(components)
+	if modFile.Module == nil {
+		return bom, nil
+	}
+
+	bom.Metadata = &cdx.Metadata{Component: &cdx.Component{
+		Type:       cdx.ComponentTypeApplication,
+		Name:       modFile.Module.Mod.Path,
+		PackageURL: golangPURL(modFile.Module.Mod.Path, ""),
+	}}
+	var properties []cdx.Property
+	for _, r := range modFile.Retract {
+		retracted := r.Low
+		if r.High != r.Low {
+			retracted = "[" + r.Low + ", " + r.High + "]"
+		}
+		properties = append(properties, cdx.Property{Name: propertyPrefix + "golang:retract", Value: retracted})
+	}
+	if len(properties) > 0 {
+		bom.Properties = &properties
+	}
+
+	return bom, nil
+}
+
+/*
+parseVendorModules converts vendor/modules.txt contents, written by go mod vendor, into pkg:golang components
+flagged as vendored. Every vendored module is listed on a line starting with a single hash, followed by
+markers & the packages copied from it. E.g.
+
+	# github.com/pkg/errors v0.9.1
+	## explicit
+	github.com/pkg/errors
+	# golang.org/x/sys v0.0.0-20220715151400-c0bba94af5f8 => golang.org/x/sys v0.15.0
+	## explicit; go 1.18
+	golang.org/x/sys/unix
+
+Replacements are applied the same way as in go.mod, lines recording wildcard replacements are skipped.
+*/
+func parseVendorModules(modulesTxt []byte) []cdx.Component {
+	var components []cdx.Component
+	explicit := make(map[int]bool)
+
+	scanner := bufio.NewScanner(bytes.NewReader(modulesTxt))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if markers, ok := strings.CutPrefix(line, "## "); ok && len(components) > 0 {
+			for _, m := range strings.Split(markers, ";") {
+				if strings.TrimSpace(m) == "explicit" {
+					explicit[len(components)-1] = true
+				}
+			}
+			continue
+		}
+
+		fields := strings.Fields(strings.TrimPrefix(line, "# "))
+		if !strings.HasPrefix(line, "# ") || len(fields) < 2 || fields[1] == "=>" {
+			continue
+		}
+
+		resolved, replaces := module.Version{Path: fields[0], Version: fields[1]}, ""
+		if len(fields) > 3 && fields[2] == "=>" {
+			replacement := module.Version{Path: fields[3]}
+			if len(fields) > 4 {
+				replacement.Version = fields[4]
+				resolved = replacement
+			}
+			replaces = fields[0] + "@" + fields[1] + " => " + replacement.String()
+		}
+
+		component := newLibraryComponent(golangPURL(resolved.Path, resolved.Version), resolved.Path, resolved.Version)
+		addProperty(&component, "golang:vendored", "true")
+		addProperty(&component, "golang:replace", replaces)
+		components = append(components, component)
+	}
+
+	for i := range components {
+		addProperty(&components[i], "golang:explicit", fmt.Sprintf("%t", explicit[i]))
+	}
+
+	return components
+}
+
+/*
+markVendoredModules flags go.mod components as either vendored or not & appends vendored modules missing from
+go.mod. Modules that aren't required explicitly are missing from go.mod files of projects targeting Go 1.16
+and older.
+*/
+func markVendoredModules(bom *cdx.BOM, vendored []cdx.Component) {
+	vendoredPURLs := make(map[string]bool, len(vendored))
+	for _, c := range vendored {
+		vendoredPURLs[c.PackageURL] = true
+	}
+
+	components := *bom.Components
+	required := make(map[string]bool, len(components))
+	for i := range components {
+		required[components[i].PackageURL] = true
+		addProperty(&components[i], "golang:vendored", fmt.Sprintf("%t", vendoredPURLs[components[i].PackageURL]))
+	}
+	for _, c := range vendored {
+		if !required[c.PackageURL] {
+			components = append(components, c)
+		}
+	}
+
+	bom.Components = bomFromComponents(components).Components
+}
+
+/*
+bomFromGoMod reads go.mod & the optional go.sum files inside the given directory & converts them to a BOM.
+When dependencies are vendored, vendor/modules.txt is used to flag vendored modules. Vendored modules alone
+are returned when go.mod can't be parsed.
+*/
+func bomFromGoMod(dir string) (*cdx.BOM, error) {
+	var vendored []cdx.Component
+	if modulesTxt, err := os.ReadFile(fp.Join(dir, "vendor", "modules.txt")); err == nil {
+		vendored = parseVendorModules(modulesTxt)
+	}
+
+	goMod, err := os.ReadFile(fp.Join(dir, "go.mod"))
+	if err != nil {
+		if len(vendored) > 0 {
+			return bomFromComponents(vendored), nil
+		}
+		return nil, err
+	}
+
+	goSum, err := os.ReadFile(fp.Join(dir, "go.sum"))
+	if err != nil {
+		goSum = nil // go.sum is optional. E.g. modules without any dependencies
+	}
+
+	bom, err := parseGoMod(goMod, goSum)
+	if err != nil {
+		if len(vendored) > 0 {
+			return bomFromComponents(vendored), nil
+		}
+		return nil, err
+	}
+	if vendored != nil {
+		markVendoredModules(bom, vendored)
+	}
+
+	return bom, nil
+}
@@ -0,0 +1,164 @@
+package collectors
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+// Gradle dependency lockfiles, generated with --write-locks
+var gradleLockfiles = []string{"gradle.lockfile", "settings-gradle.lockfile"}
+
+/*
+gradleScope maps configurations a locked module belongs to onto CycloneDX scopes. Modules on any runtime
+classpath are required, modules used only by tests are optional, while modules used only at compile time
+or by the build itself (annotation processors, settings & buildscript plugins) are excluded.
+*/
+func gradleScope(configurations []string) cdx.Scope {
+	production := false
+	for _, c := range configurations {
+		c = strings.ToLower(c)
+		if strings.Contains(c, "test") {
+			continue
+		}
+		if strings.Contains(c, "runtimeclasspath") {
+			return cdx.ScopeRequired
+		}
+		production = true
+	}
+	if production {
+		return cdx.ScopeExcluded
+	}
+
+	return cdx.ScopeOptional
+}
+
+/*
+parseGradleLockfile converts gradle.lockfile & settings-gradle.lockfile contents into pkg:maven components.
+Every line locks a single module for a list of configurations. E.g.
+
+	com.google.guava:guava:32.1.1-jre=compileClasspath,runtimeClasspath
+*/
+func parseGradleLockfile(contents []byte) ([]cdx.Component, error) {
+	var components []cdx.Component
+
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "empty=") {
+			continue
+		}
+
+		module, configurations, ok := strings.Cut(line, "=")
+		coordinates := strings.Split(module, ":")
+		if !ok || len(coordinates) != 3 {
+			return nil, fmt.Errorf("can't parse gradle lockfile: malformed line %q", line)
+		}
+
+		d := mavenDependency{GroupID: coordinates[0], ArtifactID: coordinates[1], Version: coordinates[2]}
+		component := newLibraryComponent(mavenPURL(d), d.ArtifactID, d.Version)
+		component.Group = d.GroupID
+		component.Scope = gradleScope(strings.Split(configurations, ","))
+		addProperty(&component, "gradle:configurations", configurations)
+		components = append(components, component)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("can't parse gradle lockfile: %w", err)
+	}
+
+	return uniqueComponents(components), nil
+}
+
+// isGradleVersionRange reports whether the version is a range or a dynamic version. E.g. [1.0,2.0) or 1.+
+func isGradleVersionRange(version string) bool {
+	return strings.ContainsAny(version, "[]()+,") || strings.HasPrefix(version, "latest.")
+}
+
+/*
+gradleCatalogVersion resolves a version declared in a version catalog. Versions are either plain strings
+or rich versions - preferred versions win over strict & required ones. Version ranges can't be pinned,
+so an empty string is returned for those.
+*/
+func gradleCatalogVersion(declaration interface{}, versions map[string]interface{}) string {
+	switch v := declaration.(type) {
+	case string:
+		if isGradleVersionRange(v) {
+			return ""
+		}
+		return v
+	case map[string]interface{}:
+		if ref, ok := v["ref"].(string); ok {
+			return gradleCatalogVersion(versions[ref], nil)
+		}
+		for _, key := range []string{"prefer", "strictly", "require"} {
+			if version, ok := v[key].(string); ok && !isGradleVersionRange(version) {
+				return version
+			}
+		}
+	}
+
+	return ""
+}
+
+/*
+parseGradleVersionCatalog converts libraries declared in gradle/libs.versions.toml into pkg:maven components.
+Libraries come in three notations:
+
+	guava = "com.google.guava:guava:32.1.1-jre"
+	groovy-core = { module = "org.codehaus.groovy:groovy", version.ref = "groovy" }
+	groovy-json = { group = "org.codehaus.groovy", name = "groovy-json", version = "3.0.5" }
+
+Libraries without a version (managed by platforms) or with version ranges are skipped.
+*/
+func parseGradleVersionCatalog(contents []byte) ([]cdx.Component, error) {
+	var catalog struct {
+		Versions  map[string]interface{} `toml:"versions"`
+		Libraries map[string]interface{} `toml:"libraries"`
+	}
+	if _, err := toml.Decode(string(contents), &catalog); err != nil {
+		return nil, fmt.Errorf("can't parse gradle version catalog: %w", err)
+	}
+
+	aliases := make([]string, 0, len(catalog.Libraries))
+	for alias := range catalog.Libraries {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+
+	var components []cdx.Component
+	for _, alias := range aliases {
+		var d mavenDependency
+		switch library := catalog.Libraries[alias].(type) {
+		case string:
+			coordinates := strings.Split(library, ":")
+			if len(coordinates) != 3 {
+				continue
+			}
+			d = mavenDependency{GroupID: coordinates[0], ArtifactID: coordinates[1]}
+			d.Version = gradleCatalogVersion(coordinates[2], nil)
+		case map[string]interface{}:
+			if module, ok := library["module"].(string); ok {
+				d.GroupID, d.ArtifactID, _ = strings.Cut(module, ":")
+			} else {
+				d.GroupID, _ = library["group"].(string)
+				d.ArtifactID, _ = library["name"].(string)
+			}
+			d.Version = gradleCatalogVersion(library["version"], catalog.Versions)
+		}
+		if d.GroupID == "" || d.ArtifactID == "" || d.Version == "" {
+			continue
+		}
+
+		component := newLibraryComponent(mavenPURL(d), d.ArtifactID, d.Version)
+		component.Group = d.GroupID
+		addProperty(&component, "gradle:catalog-alias", alias)
+		components = append(components, component)
+	}
+
+	return uniqueComponents(components), nil
+}
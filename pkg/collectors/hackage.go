@@ -0,0 +1,167 @@
+package collectors
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"gopkg.in/yaml.v3"
+)
+
+/*
+cabalConstraints extracts the constraints field of a cabal.project.freeze file. The field lists comma
+separated constraints & continues on indented lines. E.g.
+
+	constraints: any.aeson ==2.1.2.1,
+	             aeson -ordered-keymap,
+	             any.base ==4.17.2.0
+*/
+func cabalConstraints(contents []byte) []string {
+	var (
+		constraints []string
+		field       strings.Builder
+		inField     bool
+	)
+
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(strings.TrimSpace(line), "--") { // Comments
+			continue
+		}
+		if line != "" && line[0] != ' ' && line[0] != '\t' {
+			name, value, _ := strings.Cut(line, ":")
+			inField = strings.TrimSpace(name) == "constraints"
+			line = value
+		}
+		if inField {
+			field.WriteString(line + " ")
+		}
+	}
+
+	for _, c := range strings.Split(field.String(), ",") {
+		if c = strings.Join(strings.Fields(c), " "); c != "" {
+			constraints = append(constraints, c)
+		}
+	}
+
+	return constraints
+}
+
+/*
+parseCabalFreeze converts cabal.project.freeze contents, written by cabal freeze, into pkg:hackage components.
+Only constraints pinning exact versions are collected, while flag & installed constraints are skipped.
+Constraints qualified with setup apply to dependencies of custom Setup.hs scripts, so those are excluded.
+*/
+func parseCabalFreeze(contents []byte) (*cdx.BOM, error) {
+	constraints := cabalConstraints(contents)
+	if len(constraints) == 0 {
+		return nil, errors.New("can't parse cabal.project.freeze: no constraints found")
+	}
+
+	components := make([]cdx.Component, 0, len(constraints))
+	for _, c := range constraints {
+		qualifiedName, version, ok := strings.Cut(c, " ==")
+		if !ok {
+			continue
+		}
+		version = strings.TrimSpace(version)
+
+		scope := cdx.ScopeRequired
+		name := strings.TrimPrefix(qualifiedName, "any.")
+		if i := strings.LastIndex(name, "setup."); i != -1 && (i == 0 || name[i-1] == ':') {
+			scope, name = cdx.ScopeExcluded, name[i+len("setup."):]
+		}
+
+		component := newLibraryComponent(packageURL("hackage", "", name, version), name, version)
+		component.Scope = scope
+		components = append(components, component)
+	}
+
+	return bomFromComponents(uniqueComponents(components)), nil
+}
+
+// stackLockfile represents stack.yaml.lock files, written by stack for extra-deps & snapshots of stack.yaml.
+type stackLockfile struct {
+	Packages []struct {
+		Completed struct {
+			Hackage string `yaml:"hackage"`
+			Name    string `yaml:"name"`
+			Version string `yaml:"version"`
+			Git     string `yaml:"git"`
+			Commit  string `yaml:"commit"`
+			Subdir  string `yaml:"subdir"`
+		} `yaml:"completed"`
+	} `yaml:"packages"`
+	Snapshots []struct {
+		Original yaml.Node `yaml:"original"`
+	} `yaml:"snapshots"`
+}
+
+/*
+parseStackLock converts stack.yaml.lock contents into pkg:hackage components. Hackage packages are locked
+as name-version@sha256:hash,size where the hash covers the cabal file revision, so it's recorded as a property.
+Packages fetched from git are locked to a commit, which is recorded as a VCS reference. Packages provided by
+the snapshot aren't listed in lockfiles, the snapshot is recorded as a BOM property instead.
+*/
+func parseStackLock(contents []byte) (*cdx.BOM, error) {
+	var lockfile stackLockfile
+	if err := yaml.Unmarshal(contents, &lockfile); err != nil {
+		return nil, fmt.Errorf("can't parse stack.yaml.lock: %w", err)
+	}
+
+	components := make([]cdx.Component, 0, len(lockfile.Packages))
+	for _, p := range lockfile.Packages {
+		locked := p.Completed
+		name, version, revision := locked.Name, locked.Version, ""
+		if locked.Hackage != "" {
+			var packageIdentifier string
+			packageIdentifier, revision, _ = strings.Cut(locked.Hackage, "@")
+			i := strings.LastIndex(packageIdentifier, "-")
+			if i == -1 {
+				continue
+			}
+			name, version = packageIdentifier[:i], packageIdentifier[i+1:]
+		}
+		if name == "" {
+			continue
+		}
+
+		component := newLibraryComponent(packageURL("hackage", "", name, version), name, version)
+		component.Scope = cdx.ScopeRequired
+		addProperty(&component, "stack:cabalFileRevision", revision)
+		if locked.Git != "" {
+			vcsURL := locked.Git
+			if locked.Commit != "" {
+				vcsURL += "#" + locked.Commit
+			}
+			addExternalReference(&component, cdx.ERTypeVCS, vcsURL)
+			addProperty(&component, "stack:subdir", locked.Subdir)
+		}
+		components = append(components, component)
+	}
+
+	bom := bomFromComponents(uniqueComponents(components))
+	var properties []cdx.Property
+	for _, s := range lockfile.Snapshots {
+		snapshot := s.Original.Value
+		if s.Original.Kind == yaml.MappingNode { // Custom snapshots are referenced by URL. E.g. original: {url: ...}
+			var original struct {
+				URL string `yaml:"url"`
+			}
+			_ = s.Original.Decode(&original)
+			snapshot = original.URL
+		}
+		if snapshot != "" {
+			properties = append(properties, cdx.Property{Name: propertyPrefix + "stack:snapshot", Value: snapshot})
+		}
+	}
+	if len(properties) > 0 {
+		bom.Properties = &properties
+	}
+
+	return bom, nil
+}
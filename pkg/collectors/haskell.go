@@ -0,0 +1,90 @@
+package collectors
+
+import (
+	"context"
+	"errors"
+	"os"
+	fp "path/filepath"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	log "github.com/sirupsen/logrus"
+	"github.com/vinted/sbomsftw/pkg/bomtools"
+)
+
+// Lockfiles of cabal & stack, parsed natively without GHC.
+var haskellLockfileParsers = []struct {
+	filename string
+	parse    func([]byte) (*cdx.BOM, error)
+}{
+	{filename: "cabal.project.freeze", parse: parseCabalFreeze},
+	{filename: "stack.yaml.lock", parse: parseStackLock},
+}
+
+var errNoHaskellLockfiles = errors.New("no cabal.project.freeze or stack.yaml.lock files could be parsed")
+
+// Haskell collects dependencies of Haskell projects.
+type Haskell struct{}
+
+func NewHaskellCollector() Haskell {
+	return Haskell{}
+}
+
+// MatchLanguageFiles implements LanguageCollector interface.
+func (h Haskell) MatchLanguageFiles(isDir bool, filepath string) bool {
+	if isDir {
+		return false
+	}
+	for _, d := range strings.Split(fp.Dir(filepath), string(os.PathSeparator)) {
+		if d == "dist-newstyle" || d == ".stack-work" { // Ignore build directories
+			return false
+		}
+	}
+
+	for _, p := range haskellLockfileParsers {
+		if fp.Base(filepath) == p.filename {
+			return true
+		}
+	}
+
+	return false
+}
+
+// BootstrapLanguageFiles implements LanguageCollector interface.
+func (h Haskell) BootstrapLanguageFiles(_ context.Context, bomRoots []string) []string {
+	return SquashToDirs(bomRoots)
+}
+
+/*
+GenerateBOM implements LanguageCollector interface. Every lockfile in the bom root is parsed natively
+& the resulting BOMs are merged into one.
+*/
+func (h Haskell) GenerateBOM(_ context.Context, bomRoot string) (*cdx.BOM, error) {
+	var boms []*cdx.BOM
+	for _, p := range haskellLockfileParsers {
+		contents, err := os.ReadFile(fp.Join(bomRoot, p.filename))
+		if err != nil {
+			continue
+		}
+		bom, err := p.parse(contents)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"collector":       h,
+				"collection path": bomRoot,
+				"error":           err,
+			}).Debugf("can't parse %s", p.filename)
+			continue
+		}
+		boms = append(boms, bom)
+	}
+	if len(boms) == 0 {
+		return nil, errNoHaskellLockfiles
+	}
+
+	return bomtools.MergeSBOMs(bomtools.MergeSBOMParam{SBOMs: boms})
+}
+
+// String implements LanguageCollector interface.
+func (h Haskell) String() string {
+	return "haskell collector"
+}
@@ -0,0 +1,131 @@
+package collectors
+
+import (
+	"context"
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vinted/sbomsftw/pkg/bomtools"
+)
+
+func TestHaskellCollector(t *testing.T) {
+	t.Run("match correct package files", func(t *testing.T) {
+		haskellCollector := Haskell{}
+		assert.True(t, haskellCollector.MatchLanguageFiles(false, "cabal.project.freeze"))
+		assert.True(t, haskellCollector.MatchLanguageFiles(false, "/opt/service/stack.yaml.lock"))
+		assert.False(t, haskellCollector.MatchLanguageFiles(false, "/opt/service/stack.yaml"))
+		assert.False(t, haskellCollector.MatchLanguageFiles(false, "/opt/service/.stack-work/install/stack.yaml.lock"))
+		assert.False(t, haskellCollector.MatchLanguageFiles(false, "/opt/service/dist-newstyle/cabal.project.freeze"))
+		assert.False(t, haskellCollector.MatchLanguageFiles(true, "cabal.project.freeze"))
+	})
+
+	t.Run("bootstrap language files correctly", func(t *testing.T) {
+		got := Haskell{}.BootstrapLanguageFiles(context.Background(), []string{
+			"/tmp/some-random-dir/cabal.project.freeze",
+			"/tmp/some-random-dir/stack.yaml.lock",
+		})
+		assert.Equal(t, []string{"/tmp/some-random-dir"}, got)
+	})
+
+	t.Run("implement Stringer correctly", func(t *testing.T) {
+		assert.Equal(t, "haskell collector", Haskell{}.String())
+	})
+
+	t.Run("generate BOM natively from cabal.project.freeze", func(t *testing.T) {
+		got, err := Haskell{}.GenerateBOM(context.Background(), "../../integration/test/haskell/cabal")
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []string{
+			"pkg:hackage/Cabal@3.8.1.0",
+			"pkg:hackage/aeson@2.1.2.1",
+			"pkg:hackage/base@4.17.2.0",
+			"pkg:hackage/text@2.0.2",
+		}, purls(t, got))
+
+		components := componentsByPURL(t, got)
+		assert.Equal(t, cdx.ScopeRequired, components["pkg:hackage/aeson@2.1.2.1"].Scope)
+		assert.Equal(t, cdx.ScopeExcluded, components["pkg:hackage/Cabal@3.8.1.0"].Scope)
+	})
+
+	t.Run("generate BOM natively from stack.yaml.lock", func(t *testing.T) {
+		got, err := Haskell{}.GenerateBOM(context.Background(), "../../integration/test/haskell/stack")
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []string{
+			"pkg:hackage/acme-missiles@0.3",
+			"pkg:hackage/servant-extras@0.4.1",
+		}, purls(t, got))
+
+		components := componentsByPURL(t, got)
+		assert.Equal(t, &[]cdx.Property{{
+			Name:  "sbomsftw:stack:cabalFileRevision",
+			Value: "sha256:2ba66a092a32593880a87fb00f3213762d7bca65a687d45965778deb8694c5d1,613",
+		}}, components["pkg:hackage/acme-missiles@0.3"].Properties)
+
+		servantExtras := components["pkg:hackage/servant-extras@0.4.1"]
+		assert.Equal(t, &[]cdx.ExternalReference{{
+			Type: cdx.ERTypeVCS,
+			URL:  "https://github.com/vinted/servant-extras.git#6e2d4c7b1a3f5e8d9c0b2a4f6e8d0c2b4a6f8e0d",
+		}}, servantExtras.ExternalReferences)
+		assert.Equal(t, &[]cdx.Property{
+			{Name: "sbomsftw:stack:subdir", Value: "servant-extras-core"},
+		}, servantExtras.Properties)
+	})
+
+	t.Run("record stack snapshots", func(t *testing.T) {
+		got, err := parseStackLock([]byte(`
+packages: []
+snapshots:
+- original: lts-21.25
+- original:
+    url: https://example.com/snapshot.yaml
+`))
+		require.NoError(t, err)
+		assert.Equal(t, &[]cdx.Property{
+			{Name: "sbomsftw:stack:snapshot", Value: "lts-21.25"},
+			{Name: "sbomsftw:stack:snapshot", Value: "https://example.com/snapshot.yaml"},
+		}, got.Properties)
+	})
+
+	t.Run("keep snapshots of every project when merging", func(t *testing.T) {
+		stack, err := Haskell{}.GenerateBOM(context.Background(), "../../integration/test/haskell/stack")
+		require.NoError(t, err)
+		cabal, err := Haskell{}.GenerateBOM(context.Background(), "../../integration/test/haskell/cabal")
+		require.NoError(t, err)
+		worker, err := parseStackLock([]byte(`
+packages:
+- completed:
+    hackage: acme-missiles-0.3@sha256:2ba66a092a32593880a87fb00f3213762d7bca65a687d45965778deb8694c5d1,613
+  original:
+    hackage: acme-missiles-0.3
+snapshots:
+- original: lts-22.7
+`))
+		require.NoError(t, err)
+
+		got, err := bomtools.MergeSBOMs(bomtools.MergeSBOMParam{SBOMs: []*cdx.BOM{stack, cabal, worker}})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{
+			"pkg:hackage/Cabal@3.8.1.0",
+			"pkg:hackage/acme-missiles@0.3",
+			"pkg:hackage/aeson@2.1.2.1",
+			"pkg:hackage/base@4.17.2.0",
+			"pkg:hackage/servant-extras@0.4.1",
+			"pkg:hackage/text@2.0.2",
+		}, purls(t, got))
+		require.NotNil(t, got.Properties)
+		assert.ElementsMatch(t, []cdx.Property{
+			{Name: "sbomsftw:stack:snapshot", Value: "lts-21.25"},
+			{Name: "sbomsftw:stack:snapshot", Value: "lts-22.7"},
+		}, *got.Properties)
+	})
+
+	t.Run("return an error for lockfiles without pinned packages", func(t *testing.T) {
+		_, err := parseCabalFreeze([]byte("index-state: hackage.haskell.org 2023-10-10T09:41:25Z\n"))
+		assert.Error(t, err)
+		_, err = Haskell{}.GenerateBOM(context.Background(), "/tmp/some-random-dir")
+		assert.ErrorIs(t, err, errNoHaskellLockfiles)
+	})
+}
@@ -0,0 +1,180 @@
+package collectors
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"gopkg.in/yaml.v3"
+)
+
+// helmDependencies represents dependencies of Chart.yaml & Chart.lock files, as well as legacy requirements.yaml & requirements.lock files.
+type helmDependencies struct {
+	Dependencies []struct {
+		Name       string `yaml:"name"`
+		Version    string `yaml:"version"`
+		Repository string `yaml:"repository"`
+	} `yaml:"dependencies"`
+}
+
+// helmChartVersion pins a chart version. Version ranges & wildcards can't be pinned, so an empty string is returned for those.
+func helmChartVersion(version string) string {
+	version = strings.TrimSpace(version)
+	if strings.ContainsAny(version, "<>=~^*xX|, ") {
+		return ""
+	}
+
+	return version
+}
+
+/*
+parseHelmDependencies converts chart dependencies into pkg:helm components. Chart.yaml files hold version
+constraints, so only exact versions are kept, while Chart.lock files hold resolved versions. Repositories
+are recorded as distribution references, dependencies on local charts (file://) are skipped.
+*/
+func parseHelmDependencies(contents []byte) (*cdx.BOM, error) {
+	var chart helmDependencies
+	if err := yaml.Unmarshal(contents, &chart); err != nil {
+		return nil, fmt.Errorf("can't parse chart dependencies: %w", err)
+	}
+
+	components := make([]cdx.Component, 0, len(chart.Dependencies))
+	for _, d := range chart.Dependencies {
+		if d.Name == "" || strings.HasPrefix(d.Repository, "file://") {
+			continue
+		}
+		version := helmChartVersion(d.Version)
+		purl := packageURL("helm", "", d.Name, version, qualifier{key: "repository_url", value: d.Repository})
+		component := newLibraryComponent(purl, d.Name, version)
+		component.Scope = cdx.ScopeRequired
+		if !strings.HasPrefix(d.Repository, "@") && !strings.HasPrefix(d.Repository, "alias:") { // Aliases of locally added repositories
+			addExternalReference(&component, cdx.ERTypeDistribution, d.Repository)
+		}
+		components = append(components, component)
+	}
+
+	return bomFromComponents(uniqueComponents(components)), nil
+}
+
+/*
+imageReference extracts an image reference from the value of an image key. Kubernetes manifests reference
+images as strings, while chart values usually split them up. E.g.
+
+	image:
+	  registry: docker.io
+	  repository: bitnami/nginx
+	  tag: 1.25.3
+
+Values referencing templates or other values can't be resolved, so an empty string is returned for those.
+*/
+func imageReference(node *yaml.Node) string {
+	var reference string
+	switch node.Kind {
+	case yaml.ScalarNode:
+		if node.Tag != "!!str" {
+			return ""
+		}
+		reference = node.Value
+	case yaml.MappingNode:
+		var image struct {
+			Registry   string `yaml:"registry"`
+			Repository string `yaml:"repository"`
+			Name       string `yaml:"name"`
+			Tag        string `yaml:"tag"`
+			Digest     string `yaml:"digest"`
+		}
+		if err := node.Decode(&image); err != nil {
+			return ""
+		}
+		if image.Repository == "" {
+			image.Repository = image.Name
+		}
+		if image.Repository == "" {
+			return ""
+		}
+		reference = strings.Trim(image.Registry+"/"+image.Repository, "/")
+		if image.Tag != "" {
+			reference += ":" + image.Tag
+		}
+		if image.Digest != "" {
+			reference += "@" + image.Digest
+		}
+	}
+	if reference = strings.TrimSpace(reference); strings.ContainsAny(reference, "{}$ ") {
+		return ""
+	}
+
+	return reference
+}
+
+// imageReferences walks a YAML document & returns references found under every image key.
+func imageReferences(node *yaml.Node) []string {
+	var references []string
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, n := range node.Content {
+			references = append(references, imageReferences(n)...)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i].Value, node.Content[i+1]
+			if key == "image" {
+				if reference := imageReference(value); reference != "" {
+					references = append(references, reference)
+				}
+				continue
+			}
+			references = append(references, imageReferences(value)...)
+		}
+	}
+
+	return references
+}
+
+// isKubernetesManifest reports whether the document is a Kubernetes object, i.e. it has both apiVersion & kind keys.
+func isKubernetesManifest(document *yaml.Node) bool {
+	if len(document.Content) == 0 || document.Content[0].Kind != yaml.MappingNode {
+		return false
+	}
+	var object struct {
+		APIVersion string `yaml:"apiVersion"`
+		Kind       string `yaml:"kind"`
+	}
+
+	return document.Content[0].Decode(&object) == nil && object.APIVersion != "" && object.Kind != ""
+}
+
+/*
+parseImages converts container images referenced from YAML files into container components. Images of chart
+values files are always collected, while images of other files are collected only from Kubernetes manifests.
+Files can hold multiple documents.
+*/
+func parseImages(contents []byte, values bool) ([]cdx.Component, error) {
+	var components []cdx.Component
+
+	decoder := yaml.NewDecoder(bytes.NewReader(contents))
+	for {
+		var document yaml.Node
+		err := decoder.Decode(&document)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("can't parse YAML document: %w", err)
+		}
+		if !values && !isKubernetesManifest(&document) {
+			continue
+		}
+
+		for _, reference := range imageReferences(&document) {
+			component := parseDockerImageReference(reference).component()
+			component.Scope = cdx.ScopeRequired
+			components = append(components, component)
+		}
+	}
+
+	return components, nil
+}
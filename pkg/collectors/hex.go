@@ -0,0 +1,221 @@
+package collectors
+
+import (
+	"fmt"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+/*
+hexPURL creates a pkg:hex Package URL. Packages of private organizations are hosted in hexpm:<organization>
+repositories, the organization becomes the namespace.
+*/
+func hexPURL(name, version, repository string) string {
+	_, organization, _ := strings.Cut(repository, "hexpm:")
+
+	return packageURL("hex", organization, strings.ToLower(name), version)
+}
+
+// hexChecksums converts hex encoded SHA-256 checksums of Hex packages into CycloneDX hashes.
+func hexChecksums(checksums ...string) []cdx.Hash {
+	var hashes []cdx.Hash
+	for _, checksum := range checksums {
+		if len(checksum) == 64 {
+			hashes = append(hashes, cdx.Hash{Algorithm: cdx.HashAlgoSHA256, Value: strings.ToLower(checksum)})
+		}
+	}
+
+	return hashes
+}
+
+// hexComponent creates a pkg:hex component. Checksums are recorded as SHA-256 hashes.
+func hexComponent(name, version, repository string, checksums ...string) cdx.Component {
+	component := newLibraryComponent(hexPURL(name, version, repository), name, version)
+	addHashes(&component, hexChecksums(checksums...)...)
+	if repository != "hexpm" && !strings.HasPrefix(repository, "hexpm:") {
+		addProperty(&component, "hex:repository", repository)
+	}
+
+	return component
+}
+
+// gitHexComponent creates a pkg:hex component for dependencies fetched from git. The revision doubles as the version.
+func gitHexComponent(name, repository, revision string) cdx.Component {
+	component := newLibraryComponent(hexPURL(name, revision, "hexpm"), name, revision)
+	vcsURL := repository
+	if revision != "" {
+		vcsURL += "#" + revision
+	}
+	addExternalReference(&component, cdx.ERTypeVCS, vcsURL)
+
+	return component
+}
+
+/*
+parseMixLockfile converts mix.lock contents into pkg:hex components & preserves the dependency graph encoded
+in the lockfile as CycloneDX dependencies. The lockfile is an Elixir map of locked packages. E.g.
+
+	"cowboy": {:hex, :cowboy, "2.10.0", "<inner checksum>", [:make, :rebar3], [{:cowlib, "2.12.1", [hex: :cowlib, repo: "hexpm", optional: false]}], "hexpm", "<outer checksum>"},
+	"phoenix": {:git, "https://github.com/phoenixframework/phoenix.git", "<commit>", [branch: "main"]},
+
+Both the inner (package contents) & the outer (package tarball) checksums are recorded as SHA-256 hashes.
+Lockfiles written by ancient mix releases lack the repository & the outer checksum.
+*/
+func parseMixLockfile(contents []byte) (*cdx.BOM, error) {
+	terms, err := parseBEAMTerms(contents)
+	if err != nil {
+		return nil, fmt.Errorf("can't parse mix.lock: %w", err)
+	}
+	var lockfile map[string]interface{}
+	if len(terms) == 1 {
+		lockfile, _ = terms[0].(map[string]interface{})
+	}
+	if lockfile == nil {
+		return nil, fmt.Errorf("can't parse mix.lock: expected a single map")
+	}
+
+	apps := sortedBEAMKeys(lockfile)
+	purls := make(map[string]string, len(apps)) // Dependencies are referenced by their app names
+	components := make([]cdx.Component, 0, len(apps))
+	requirements := make(map[string][]interface{}, len(apps))
+
+	for _, app := range apps {
+		entry, ok := lockfile[app].(beamTuple)
+		if !ok {
+			continue
+		}
+
+		var component cdx.Component
+		switch beamString(entry, 0) {
+		case "hex":
+			repository := beamString(entry, 6)
+			if repository == "" {
+				repository = "hexpm"
+			}
+			component = hexComponent(beamString(entry, 1), beamString(entry, 2), repository,
+				beamString(entry, 3), beamString(entry, 7))
+			if len(entry) > 5 {
+				requirements[app], _ = entry[5].([]interface{})
+			}
+		case "git":
+			component = gitHexComponent(app, beamString(entry, 1), beamString(entry, 2))
+		default:
+			continue // Path dependencies aren't locked
+		}
+		purls[app] = component.PackageURL
+		components = append(components, component)
+	}
+
+	dependencies := make([]cdx.Dependency, 0, len(components))
+	for _, app := range apps {
+		purl, ok := purls[app]
+		if !ok {
+			continue
+		}
+		dependsOn := []string{}
+		for _, r := range requirements[app] {
+			requirement, ok := r.(beamTuple)
+			if !ok {
+				continue
+			}
+			if dependency, ok := purls[beamString(requirement, 0)]; ok {
+				dependsOn = append(dependsOn, dependency)
+			}
+		}
+		dependencies = append(dependencies, cdx.Dependency{Ref: purl, Dependencies: &dependsOn})
+	}
+
+	bom := bomFromComponents(uniqueComponents(components))
+	bom.Dependencies = &dependencies
+
+	return bom, nil
+}
+
+/*
+parseRebarLockfile converts rebar.lock contents into pkg:hex components. Current lockfiles hold a versioned
+list of locked packages followed by their checksums, while legacy ones hold just the list. E.g.
+
+	{"1.2.0",
+	[{<<"cowboy">>,{pkg,<<"cowboy">>,<<"2.10.0">>},0},
+	 {<<"cowlib">>,{pkg,<<"cowlib">>,<<"2.12.1">>},1}]}.
+	[{pkg_hash,[{<<"cowboy">>, <<"<inner checksum>">>}, ...]},
+	 {pkg_hash_ext,[{<<"cowboy">>, <<"<outer checksum>">>}, ...]}].
+
+Packages locked at level 0 are direct dependencies, deeper levels are transitive ones.
+*/
+func parseRebarLockfile(contents []byte) (*cdx.BOM, error) {
+	terms, err := parseBEAMTerms(contents)
+	if err != nil {
+		return nil, fmt.Errorf("can't parse rebar.lock: %w", err)
+	}
+	if len(terms) == 0 {
+		return nil, fmt.Errorf("can't parse rebar.lock: no terms found")
+	}
+
+	var locked []interface{}
+	switch t := terms[0].(type) {
+	case beamTuple: // {"1.2.0", [...]}
+		if len(t) == 2 {
+			locked, _ = t[1].([]interface{})
+		}
+	case []interface{}:
+		locked = t
+	}
+	if locked == nil {
+		return nil, fmt.Errorf("can't parse rebar.lock: locked packages not found")
+	}
+
+	checksums := make(map[string]map[string]string)
+	if len(terms) > 1 {
+		sections, _ := terms[1].([]interface{})
+		for _, s := range sections {
+			section, ok := s.(beamTuple)
+			if !ok || len(section) != 2 {
+				continue
+			}
+			hashes, _ := section[1].([]interface{})
+			checksums[beamString(section, 0)] = make(map[string]string, len(hashes))
+			for _, h := range hashes {
+				if hash, ok := h.(beamTuple); ok {
+					checksums[beamString(section, 0)][beamString(hash, 0)] = beamString(hash, 1)
+				}
+			}
+		}
+	}
+
+	components := make([]cdx.Component, 0, len(locked))
+	for _, l := range locked {
+		entry, ok := l.(beamTuple)
+		if !ok || len(entry) != 3 {
+			continue
+		}
+		app := beamString(entry, 0)
+		source, _ := entry[1].(beamTuple)
+		level, _ := entry[2].(int)
+
+		var component cdx.Component
+		switch beamString(source, 0) {
+		case "pkg": // {pkg, Name, Version} or {pkg, Name, Version, InnerChecksum, OuterChecksum}
+			inner, outer := beamString(source, 3), beamString(source, 4)
+			if inner == "" {
+				inner, outer = checksums["pkg_hash"][app], checksums["pkg_hash_ext"][app]
+			}
+			component = hexComponent(beamString(source, 1), beamString(source, 2), "hexpm", inner, outer)
+		case "git": // {git, URL, {ref, Commit}}
+			var revision string
+			if len(source) > 2 {
+				if ref, ok := source[2].(beamTuple); ok {
+					revision = beamString(ref, 1)
+				}
+			}
+			component = gitHexComponent(app, beamString(source, 1), revision)
+		default:
+			continue
+		}
+		addProperty(&component, "rebar:indirect", fmt.Sprintf("%t", level > 0))
+		components = append(components, component)
+	}
+
+	return bomFromComponents(uniqueComponents(components)), nil
+}
@@ -10,7 +10,23 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
-var supportedJSFiles = []string{"yarn.lock", "bower.json", "package.json", "pnpm-lock.yaml", "package-lock.json"}
+var supportedJSFiles = []string{
+	"yarn.lock", "bower.json", "package.json", "pnpm-lock.yaml", "package-lock.json", "npm-shrinkwrap.json",
+	"deno.lock", "bun.lock",
+}
+
+// Lockfiles that are parsed natively, without installing dependencies or running cdxgen.
+var jsLockfileParsers = []struct {
+	filename string
+	parse    func([]byte) ([]cdx.Component, error)
+}{
+	{filename: "npm-shrinkwrap.json", parse: parseNPMLockfile},
+	{filename: "package-lock.json", parse: parseNPMLockfile},
+	{filename: "yarn.lock", parse: parseYarnLockfile},
+	{filename: "pnpm-lock.yaml", parse: parsePNPMLockfile},
+	{filename: "deno.lock", parse: parseDenoLockfile},
+	{filename: "bun.lock", parse: parseBunLockfile},
+}
 
 type JS struct {
 	executor shellExecutor
@@ -46,19 +62,86 @@ func (j JS) String() string {
 	return "javascript collector"
 }
 
-// GenerateBOM implements LanguageCollector interface
+/*
+GenerateBOM implements LanguageCollector interface. Lockfiles found in bomRoot are parsed natively,
+cdxgen is used only when none of them can be parsed.
+*/
 func (j JS) GenerateBOM(ctx context.Context, bomRoot string) (*cdx.BOM, error) {
 	const language = "javascript"
+
+	var components []cdx.Component
+	parsed := false
+	for _, p := range jsLockfileParsers {
+		contents, err := os.ReadFile(fp.Join(bomRoot, p.filename))
+		if err != nil {
+			continue
+		}
+		lockfileComponents, err := p.parse(contents)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"collector":       j,
+				"collection path": bomRoot,
+				"error":           err,
+			}).Debugf("can't parse %s natively", p.filename)
+			continue
+		}
+		components = append(components, lockfileComponents...)
+		parsed = true
+	}
+
+	if parsed {
+		return bomFromComponents(uniqueComponents(components)), nil
+	}
+
 	return j.executor.bomFromCdxgen(ctx, bomRoot, language, false)
 }
 
-// BootstrapLanguageFiles implements LanguageCollector interface
+/*
+pnpmWorkspacePackages returns absolute paths of every workspace package listed in pnpm-lock.yaml
+files found among bomRoots. Dependencies of these packages are already locked by the workspace lockfile.
+*/
+func (j JS) pnpmWorkspacePackages(bomRoots []string) map[string]bool {
+	workspacePackages := make(map[string]bool)
+
+	for _, r := range bomRoots {
+		if fp.Base(r) != "pnpm-lock.yaml" {
+			continue
+		}
+		contents, err := os.ReadFile(r)
+		if err != nil {
+			continue
+		}
+		importers, err := pnpmImporterPaths(contents)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"collector": j,
+				"error":     err,
+			}).Debugf("can't read pnpm workspace packages from: %s", r)
+			continue
+		}
+		for _, importer := range importers {
+			workspacePackages[fp.Join(fp.Dir(r), importer)] = true
+		}
+	}
+
+	return workspacePackages
+}
+
+/*
+BootstrapLanguageFiles implements LanguageCollector interface. Directories that only have package.json
+are bootstrapped by installing dependencies, unless they are pnpm workspace packages - those are skipped
+altogether, because the workspace lockfile already covers them.
+*/
 func (j JS) BootstrapLanguageFiles(ctx context.Context, bomRoots []string) []string {
 	const bootstrapCmd = "pnpm install || npm install || yarn install"
 	bootstrappedRoots := make([]string, 0, len(bomRoots))
+	workspacePackages := j.pnpmWorkspacePackages(bomRoots)
 
 	for dir, files := range SplitPaths(bomRoots) {
 		if len(files) == 1 && files[0] == "package.json" { // Create a lock file if none exist yet
+			if workspacePackages[dir] {
+				continue
+			}
 			if err := j.executor.shellOut(ctx, dir, bootstrapCmd); err != nil {
 				log.WithFields(log.Fields{
 					"collector": j,
@@ -2,10 +2,13 @@ package collectors
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
 	cdx "github.com/CycloneDX/cyclonedx-go"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestJSCollector(t *testing.T) {
@@ -41,7 +44,7 @@ func TestJSCollector(t *testing.T) {
 
 	t.Run("match correct package files", func(t *testing.T) {
 		jsCollector := JS{}
-		for _, f := range []string{"/opt/yarn.lock", "bower.json", "package.json", "pnpm-lock.yaml", "package-lock.json"} {
+		for _, f := range []string{"/opt/yarn.lock", "bower.json", "package.json", "pnpm-lock.yaml", "package-lock.json", "npm-shrinkwrap.json", "deno.lock", "bun.lock"} {
 			assert.True(t, jsCollector.MatchLanguageFiles(false, f))
 		}
 		assert.False(t, jsCollector.MatchLanguageFiles(false, "/etc/passwd"))
@@ -54,4 +57,270 @@ func TestJSCollector(t *testing.T) {
 	t.Run("implement Stringer correctly", func(t *testing.T) {
 		assert.Equal(t, "javascript collector", JS{}.String())
 	})
+	t.Run("generate BOM natively from package-lock.json v3", func(t *testing.T) {
+		executor := new(mockShellExecutor)
+		got, err := JS{executor: executor}.GenerateBOM(context.Background(), "../../integration/test/js/npm-v3")
+		require.NoError(t, err)
+		executor.AssertNotCalled(t, "bomFromCdxgen")
+
+		components := componentsByPURL(t, got)
+		scopes := make(map[string]cdx.Scope)
+		for purl, c := range components {
+			scopes[purl] = c.Scope
+		}
+		assert.Equal(t, map[string]cdx.Scope{
+			"pkg:npm/%40babel/runtime@7.22.5":     cdx.ScopeRequired,
+			"pkg:npm/regenerator-runtime@0.13.11": cdx.ScopeRequired,
+			"pkg:npm/fsevents@2.3.2":              cdx.ScopeOptional,
+			"pkg:npm/jest@29.5.0":                 cdx.ScopeOptional,
+			"pkg:npm/lodash@4.17.21":              cdx.ScopeRequired,
+			"pkg:npm/react@18.2.0":                cdx.ScopeRequired,
+		}, scopes)
+
+		lodash := components["pkg:npm/lodash@4.17.21"]
+		assert.Equal(t, &[]cdx.Hash{{
+			Algorithm: cdx.HashAlgoSHA512,
+			Value:     "bf690311ee7b95e713ba568322e3533f2dd1cb880b189e99d4edef13592b81764daec43e2c54c61d5c558dc5c63a1b286b48fd8fb64a399ee0e30e58f2d6d9f1",
+		}}, lodash.Hashes)
+		assert.Equal(t, &[]cdx.ExternalReference{{
+			Type: cdx.ERTypeDistribution,
+			URL:  "https://registry.npmjs.org/lodash/-/lodash-4.17.21.tgz",
+		}}, lodash.ExternalReferences)
+	})
+
+	t.Run("parse package-lock.json v1 correctly", func(t *testing.T) {
+		contents, err := os.ReadFile("../../integration/test/js/npm-v1/package-lock.json")
+		require.NoError(t, err)
+
+		got, err := parseNPMLockfile(contents)
+		require.NoError(t, err)
+
+		bom := bomFromComponents(got)
+		components := componentsByPURL(t, bom)
+		assert.ElementsMatch(t, []string{
+			"pkg:npm/debug@2.6.9",
+			"pkg:npm/left-pad@5fa0ba7a2e0e4e8eb7c4f7a85e0b9d0e9b1e3b8c",
+			"pkg:npm/ms@2.0.0",
+			"pkg:npm/ms@2.1.2",
+		}, purls(t, bom))
+		assert.Equal(t, cdx.ScopeOptional, components["pkg:npm/ms@2.1.2"].Scope)
+		assert.Equal(t, &[]cdx.ExternalReference{{
+			Type: cdx.ERTypeVCS,
+			URL:  "github:stevemao/left-pad#5fa0ba7a2e0e4e8eb7c4f7a85e0b9d0e9b1e3b8c",
+		}}, components["pkg:npm/left-pad@5fa0ba7a2e0e4e8eb7c4f7a85e0b9d0e9b1e3b8c"].ExternalReferences)
+	})
+	t.Run("parse classic yarn.lock correctly", func(t *testing.T) {
+		executor := new(mockShellExecutor)
+		got, err := JS{executor: executor}.GenerateBOM(context.Background(), "../../integration/test/js/yarn-classic")
+		require.NoError(t, err)
+		executor.AssertNotCalled(t, "bomFromCdxgen")
+
+		assert.ElementsMatch(t, []string{
+			"pkg:npm/%40babel/code-frame@7.12.13",
+			"pkg:npm/lodash@4.17.21",
+			"pkg:npm/react@18.2.0",
+			"pkg:npm/tiny-lib@1.2.0",
+		}, purls(t, got))
+
+		components := componentsByPURL(t, got)
+		assert.Equal(t, &[]cdx.Hash{
+			{Algorithm: cdx.HashAlgoSHA1, Value: "679591c564c3bffaae8454cf0b3df370c3d6911c"},
+		}, components["pkg:npm/lodash@4.17.21"].Hashes)
+		assert.Equal(t, &[]cdx.ExternalReference{{
+			Type: cdx.ERTypeDistribution,
+			URL:  "https://registry.yarnpkg.com/lodash/-/lodash-4.17.21.tgz",
+		}}, components["pkg:npm/lodash@4.17.21"].ExternalReferences)
+		assert.Equal(t, &[]cdx.ExternalReference{{
+			Type: cdx.ERTypeVCS,
+			URL:  "git+https://github.com/vinted/tiny-lib.git#3c2f8e4a1b5d6e7f8091a2b3c4d5e6f708192a3b",
+		}}, components["pkg:npm/tiny-lib@1.2.0"].ExternalReferences)
+	})
+
+	t.Run("parse berry yarn.lock correctly", func(t *testing.T) {
+		contents, err := os.ReadFile("../../integration/test/js/yarn-berry/yarn.lock")
+		require.NoError(t, err)
+
+		got, err := parseYarnLockfile(contents)
+		require.NoError(t, err)
+
+		bom := bomFromComponents(got)
+		assert.ElementsMatch(t, []string{
+			"pkg:npm/%40babel/code-frame@7.12.13",
+			"pkg:npm/react@18.2.0",
+			"pkg:npm/resolve@1.22.1",
+			"pkg:npm/tiny-lib@1.2.0",
+		}, purls(t, bom))
+
+		components := componentsByPURL(t, bom)
+		assert.Nil(t, components["pkg:npm/react@18.2.0"].Hashes)
+		assert.Equal(t, &[]cdx.Property{{
+			Name:  "sbomsftw:yarn:checksum",
+			Value: "10c0/b562d9b569b0cb315e44b48099f7712283d93df36b19a39a67c254c6686479d3980b7f013dc931f4a5a3ae7645eae6386b4aa5eea933baa54ecd0f9acb0902b8",
+		}}, components["pkg:npm/react@18.2.0"].Properties)
+		assert.Contains(t, *components["pkg:npm/resolve@1.22.1"].Properties, cdx.Property{
+			Name:  "sbomsftw:yarn:patch",
+			Value: "patch:resolve@npm%3A1.22.1#optional!builtin<compat/resolve>::version=1.22.1&hash=c3c19d",
+		})
+		assert.Equal(t, &[]cdx.ExternalReference{{
+			Type: cdx.ERTypeVCS,
+			URL:  "https://github.com/vinted/tiny-lib.git#commit=3c2f8e4a1b5d6e7f8091a2b3c4d5e6f708192a3b",
+		}}, components["pkg:npm/tiny-lib@1.2.0"].ExternalReferences)
+	})
+	t.Run("parse every pnpm-lock.yaml version correctly", func(t *testing.T) {
+		scopesOf := func(dir string) map[string]cdx.Scope {
+			contents, err := os.ReadFile(filepath.Join("../../integration/test/js", dir, "pnpm-lock.yaml"))
+			require.NoError(t, err)
+			got, err := parsePNPMLockfile(contents)
+			require.NoError(t, err)
+
+			scopes := make(map[string]cdx.Scope)
+			for _, c := range got {
+				scopes[c.PackageURL] = c.Scope
+			}
+			return scopes
+		}
+
+		assert.Equal(t, map[string]cdx.Scope{
+			"pkg:npm/jest@29.5.0":          cdx.ScopeOptional,
+			"pkg:npm/js-tokens@4.0.0":      cdx.ScopeRequired,
+			"pkg:npm/loose-envify@1.4.0":   cdx.ScopeRequired,
+			"pkg:npm/react-dom@18.2.0":     cdx.ScopeRequired,
+			"pkg:npm/react@18.2.0":         cdx.ScopeRequired,
+			"pkg:npm/string_decoder@1.3.0": cdx.ScopeRequired,
+		}, scopesOf("pnpm-v5"))
+
+		assert.Equal(t, map[string]cdx.Scope{
+			"pkg:npm/fsevents@2.3.2":     cdx.ScopeOptional,
+			"pkg:npm/js-tokens@4.0.0":    cdx.ScopeRequired,
+			"pkg:npm/loose-envify@1.4.0": cdx.ScopeRequired,
+			"pkg:npm/react-dom@18.2.0":   cdx.ScopeRequired,
+			"pkg:npm/react@18.2.0":       cdx.ScopeRequired,
+			"pkg:npm/string-width@4.2.3": cdx.ScopeRequired,
+		}, scopesOf("pnpm-v6"))
+
+		assert.Equal(t, map[string]cdx.Scope{
+			"pkg:npm/jest@29.5.0":        cdx.ScopeOptional,
+			"pkg:npm/js-tokens@4.0.0":    cdx.ScopeRequired,
+			"pkg:npm/loose-envify@1.4.0": cdx.ScopeRequired,
+			"pkg:npm/react-dom@18.2.0":   cdx.ScopeRequired,
+			"pkg:npm/react@18.2.0":       cdx.ScopeRequired,
+			"pkg:npm/string-width@4.2.3": cdx.ScopeRequired,
+		}, scopesOf("pnpm-v9"))
+	})
+
+	t.Run("map pnpm importers to workspace packages", func(t *testing.T) {
+		executor := new(mockShellExecutor)
+		got, err := JS{executor: executor}.GenerateBOM(context.Background(), "../../integration/test/js/pnpm-v9")
+		require.NoError(t, err)
+		executor.AssertNotCalled(t, "bomFromCdxgen")
+
+		components := componentsByPURL(t, got)
+		assert.Equal(t, &[]cdx.Property{
+			{Name: "sbomsftw:pnpm:importer", Value: "."},
+			{Name: "sbomsftw:pnpm:importer", Value: "packages/ui-kit"},
+		}, components["pkg:npm/react@18.2.0"].Properties)
+		assert.Equal(t, &[]cdx.Property{
+			{Name: "sbomsftw:pnpm:importer", Value: "packages/ui-kit"},
+		}, components["pkg:npm/string-width@4.2.3"].Properties)
+	})
+
+	t.Run("don't bootstrap pnpm workspace packages", func(t *testing.T) {
+		executor := new(mockShellExecutor)
+		workspaceRoot, err := filepath.Abs("../../integration/test/js/pnpm-v9")
+		require.NoError(t, err)
+
+		got := JS{executor: executor}.BootstrapLanguageFiles(context.Background(), []string{
+			filepath.Join(workspaceRoot, "pnpm-lock.yaml"),
+			filepath.Join(workspaceRoot, "packages/ui-kit/package.json"),
+		})
+		executor.AssertNotCalled(t, "shellOut")
+		assert.Equal(t, []string{workspaceRoot}, got)
+	})
+
+	t.Run("parse deno.lock v4 correctly", func(t *testing.T) {
+		executor := new(mockShellExecutor)
+		got, err := JS{executor: executor}.GenerateBOM(context.Background(), "../../integration/test/js/deno-v4")
+		require.NoError(t, err)
+		executor.AssertNotCalled(t, "bomFromCdxgen")
+
+		assert.ElementsMatch(t, []string{
+			"pkg:generic/deno.land/std%400.200.0/fmt/colors.ts",
+			"pkg:generic/esm.sh/v135/zod%403.22.4/lib/index.mjs",
+			"pkg:generic/jsr.io/%40std/assert@1.0.6",
+			"pkg:generic/jsr.io/%40std/internal@1.0.4",
+			"pkg:generic/jsr.io/%40std/path@1.0.6",
+			"pkg:npm/chalk@5.3.0",
+			"pkg:npm/preact-render-to-string@6.2.2",
+			"pkg:npm/preact@10.19.6",
+		}, purls(t, got))
+
+		components := componentsByPURL(t, got)
+		assert.Equal(t, &[]cdx.Hash{{
+			Algorithm: cdx.HashAlgoSHA256,
+			Value:     "ab2c55f902b380cf28e0eec501b4906e4c1960d13f00e11cfbcd21de15f18fed",
+		}}, components["pkg:generic/jsr.io/%40std/path@1.0.6"].Hashes)
+		assert.Equal(t, &[]cdx.ExternalReference{{
+			Type: cdx.ERTypeDistribution,
+			URL:  "https://jsr.io/@std/path/1.0.6",
+		}}, components["pkg:generic/jsr.io/%40std/path@1.0.6"].ExternalReferences)
+
+		colors := components["pkg:generic/deno.land/std%400.200.0/fmt/colors.ts"]
+		assert.Equal(t, "https://deno.land/std@0.200.0/fmt/colors.ts", colors.Name)
+		assert.Equal(t, &[]cdx.Hash{{
+			Algorithm: cdx.HashAlgoSHA256,
+			Value:     "1ab1d2397a2ab7de00b9e77dff3eab1c2bc2aa3bc85ed3a7f8bbd4b4ddb6ef3c",
+		}}, colors.Hashes)
+		assert.Equal(t, cdx.HashAlgoSHA512, (*components["pkg:npm/chalk@5.3.0"].Hashes)[0].Algorithm)
+	})
+
+	t.Run("parse deno.lock v3 correctly", func(t *testing.T) {
+		contents, err := os.ReadFile("../../integration/test/js/deno-v3/deno.lock")
+		require.NoError(t, err)
+
+		got, err := parseDenoLockfile(contents)
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []string{
+			"pkg:generic/deno.land/x/oak%40v12.6.1/mod.ts",
+			"pkg:generic/jsr.io/%40std/fmt@0.221.0",
+			"pkg:npm/%40types/node@18.16.19",
+			"pkg:npm/ms@2.1.3",
+		}, purls(t, bomFromComponents(got)))
+	})
+
+	t.Run("parse bun.lock correctly", func(t *testing.T) {
+		executor := new(mockShellExecutor)
+		got, err := JS{executor: executor}.GenerateBOM(context.Background(), "../../integration/test/js/bun")
+		require.NoError(t, err)
+		executor.AssertNotCalled(t, "bomFromCdxgen")
+
+		components := componentsByPURL(t, got)
+		scopes := make(map[string]cdx.Scope)
+		for purl, c := range components {
+			scopes[purl] = c.Scope
+		}
+		assert.Equal(t, map[string]cdx.Scope{
+			"pkg:npm/%40acme/tokens@2.1.0":                              cdx.ScopeRequired,
+			"pkg:npm/fsevents@2.3.2":                                    cdx.ScopeOptional,
+			"pkg:npm/js-tokens@3.0.2":                                   cdx.ScopeRequired,
+			"pkg:npm/js-tokens@4.0.0":                                   cdx.ScopeRequired,
+			"pkg:npm/loose-envify@1.4.0":                                cdx.ScopeRequired,
+			"pkg:npm/react@18.2.0":                                      cdx.ScopeRequired,
+			"pkg:npm/tiny-lib@3c2f8e4a1b5d6e7f8091a2b3c4d5e6f708192a3b": cdx.ScopeRequired,
+			"pkg:npm/typescript@5.4.5":                                  cdx.ScopeOptional,
+		}, scopes)
+
+		assert.Equal(t, &[]cdx.Hash{{
+			Algorithm: cdx.HashAlgoSHA512,
+			Value:     "ff722331d6f62fd41b05d5a25b97b73f6fe7a70301694f661c24825333659f464261b71f4ec19b4c9ad4fe419e99d1f6216981da2a19fb3931b66aba834f5f19",
+		}}, components["pkg:npm/react@18.2.0"].Hashes)
+		assert.Equal(t, &[]cdx.ExternalReference{{
+			Type: cdx.ERTypeDistribution,
+			URL:  "https://npm.acme.dev/",
+		}}, components["pkg:npm/%40acme/tokens@2.1.0"].ExternalReferences)
+		assert.Equal(t, &[]cdx.ExternalReference{{
+			Type: cdx.ERTypeVCS,
+			URL:  "github:vinted/tiny-lib#3c2f8e4a1b5d6e7f8091a2b3c4d5e6f708192a3b",
+		}}, components["pkg:npm/tiny-lib@3c2f8e4a1b5d6e7f8091a2b3c4d5e6f708192a3b"].ExternalReferences)
+	})
 }
@@ -0,0 +1,87 @@
+package collectors
+
+import (
+	"context"
+	"errors"
+	"os"
+	fp "path/filepath"
+	"regexp"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	log "github.com/sirupsen/logrus"
+	"github.com/vinted/sbomsftw/pkg/bomtools"
+)
+
+// Manifest.toml & JuliaManifest.toml files, optionally specific to a Julia version. E.g. Manifest-v1.10.toml
+var juliaManifestPattern = regexp.MustCompile(`^(Julia)?Manifest(-v\d+\.\d+)?\.toml$`)
+
+var errNoJuliaManifests = errors.New("no Manifest.toml files could be parsed")
+
+// Julia collects dependencies of Julia projects.
+type Julia struct{}
+
+func NewJuliaCollector() Julia {
+	return Julia{}
+}
+
+// MatchLanguageFiles implements LanguageCollector interface.
+func (j Julia) MatchLanguageFiles(isDir bool, filepath string) bool {
+	if isDir {
+		return false
+	}
+	for _, d := range strings.Split(fp.Dir(filepath), string(os.PathSeparator)) {
+		if d == ".julia" { // Ignore package depots
+			return false
+		}
+	}
+
+	return juliaManifestPattern.MatchString(fp.Base(filepath))
+}
+
+// BootstrapLanguageFiles implements LanguageCollector interface.
+func (j Julia) BootstrapLanguageFiles(_ context.Context, bomRoots []string) []string {
+	return SquashToDirs(bomRoots)
+}
+
+/*
+GenerateBOM implements LanguageCollector interface. Every manifest in the bom root is parsed natively,
+without requiring Julia, & the resulting BOMs are merged into one.
+*/
+func (j Julia) GenerateBOM(_ context.Context, bomRoot string) (*cdx.BOM, error) {
+	entries, err := os.ReadDir(bomRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	var boms []*cdx.BOM
+	for _, e := range entries {
+		if e.IsDir() || !juliaManifestPattern.MatchString(e.Name()) {
+			continue
+		}
+		contents, err := os.ReadFile(fp.Join(bomRoot, e.Name()))
+		if err != nil {
+			continue
+		}
+		bom, err := parseJuliaManifest(contents)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"collector":       j,
+				"collection path": bomRoot,
+				"error":           err,
+			}).Debugf("can't parse %s", e.Name())
+			continue
+		}
+		boms = append(boms, bom)
+	}
+	if len(boms) == 0 {
+		return nil, errNoJuliaManifests
+	}
+
+	return bomtools.MergeSBOMs(bomtools.MergeSBOMParam{SBOMs: boms})
+}
+
+// String implements LanguageCollector interface.
+func (j Julia) String() string {
+	return "julia collector"
+}
@@ -0,0 +1,99 @@
+package collectors
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJuliaCollector(t *testing.T) {
+	t.Run("match correct package files", func(t *testing.T) {
+		juliaCollector := Julia{}
+		assert.True(t, juliaCollector.MatchLanguageFiles(false, "Manifest.toml"))
+		assert.True(t, juliaCollector.MatchLanguageFiles(false, "/opt/model/JuliaManifest.toml"))
+		assert.True(t, juliaCollector.MatchLanguageFiles(false, "/opt/model/Manifest-v1.10.toml"))
+		assert.False(t, juliaCollector.MatchLanguageFiles(false, "/opt/model/Project.toml"))
+		assert.False(t, juliaCollector.MatchLanguageFiles(false, "/opt/.julia/environments/v1.9/Manifest.toml"))
+		assert.False(t, juliaCollector.MatchLanguageFiles(true, "Manifest.toml"))
+	})
+
+	t.Run("bootstrap language files correctly", func(t *testing.T) {
+		got := Julia{}.BootstrapLanguageFiles(context.Background(), []string{
+			"/tmp/some-random-dir/Manifest.toml",
+			"/tmp/some-random-dir/Manifest-v1.10.toml",
+		})
+		assert.Equal(t, []string{"/tmp/some-random-dir"}, got)
+	})
+
+	t.Run("implement Stringer correctly", func(t *testing.T) {
+		assert.Equal(t, "julia collector", Julia{}.String())
+	})
+
+	t.Run("generate BOM natively from Manifest.toml", func(t *testing.T) {
+		got, err := Julia{}.GenerateBOM(context.Background(), "../../integration/test/julia")
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []string{
+			"pkg:julia/CSV@0.10.11",
+			"pkg:julia/CodecZlib@0.7.2",
+			"pkg:julia/LocalPkg@0.1.0",
+			"pkg:julia/Parsers@2.7.2",
+			"pkg:julia/VintedTools@0.3.0",
+		}, purls(t, got))
+
+		components := componentsByPURL(t, got)
+		assert.Equal(t, &[]cdx.Property{
+			{Name: "sbomsftw:julia:gitTreeSHA1", Value: "44dbf560808d49041989b8a96cae4cffbeb7966a"},
+		}, components["pkg:julia/CSV@0.10.11"].Properties)
+		assert.Equal(t, &[]cdx.ExternalReference{
+			{Type: cdx.ERTypeVCS, URL: "https://github.com/vinted/VintedTools.jl.git"},
+		}, components["pkg:julia/VintedTools@0.3.0"].ExternalReferences)
+		assert.Equal(t, &[]cdx.ExternalReference{
+			{Type: cdx.ERTypeOther, URL: "../LocalPkg"},
+		}, components["pkg:julia/LocalPkg@0.1.0"].ExternalReferences)
+	})
+
+	t.Run("identify packages by UUIDs & preserve the dependency graph", func(t *testing.T) {
+		contents, err := os.ReadFile("../../integration/test/julia/Manifest.toml")
+		require.NoError(t, err)
+		got, err := parseJuliaManifest(contents)
+		require.NoError(t, err)
+
+		const csv = "pkg:julia/CSV@0.10.11?uuid=336ed68f-0bac-5ca0-87d4-7b16caf5d00b"
+		assert.Contains(t, purls(t, got), csv)
+
+		dependencies := make(map[string][]string)
+		for _, d := range *got.Dependencies {
+			dependencies[d.Ref] = *d.Dependencies
+		}
+		// Standard libraries such as Dates are skipped
+		assert.Equal(t, []string{
+			"pkg:julia/CodecZlib@0.7.2?uuid=944b1d66-785c-5afd-91f1-9de20f533193",
+			"pkg:julia/Parsers@2.7.2?uuid=69de0a69-1ddd-5017-9359-2bf0b02dc9f0",
+		}, dependencies[csv])
+		assert.Equal(t, &[]cdx.Property{{Name: "sbomsftw:julia:version", Value: "1.9.3"}}, got.Properties)
+	})
+
+	t.Run("parse manifests written by Julia 1.6 & older", func(t *testing.T) {
+		got, err := parseJuliaManifest([]byte(`
+[[Example]]
+git-tree-sha1 = "46e44e869b4d90b96bd8ed1fdcf32244fddfb6cc"
+uuid = "7876af07-990d-54b4-ab0e-23690620f79a"
+version = "0.5.3"
+
+[[Printf]]
+uuid = "de0858da-6303-5e67-8744-51eddeeeb8d7"
+`))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pkg:julia/Example@0.5.3?uuid=7876af07-990d-54b4-ab0e-23690620f79a"}, purls(t, got))
+	})
+
+	t.Run("return an error for malformed Manifest.toml", func(t *testing.T) {
+		_, err := parseJuliaManifest([]byte(`[[deps.CSV]`))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,144 @@
+package collectors
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/BurntSushi/toml"
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+/*
+juliaManifest represents Manifest.toml files of Julia projects. Format 2.0 manifests, written by Julia 1.7 &
+newer, nest packages under the deps table, while older manifests keep packages at the top level.
+*/
+type juliaManifest struct {
+	JuliaVersion   string                    `toml:"julia_version"`
+	ManifestFormat string                    `toml:"manifest_format"`
+	Deps           map[string][]juliaPackage `toml:"deps"`
+}
+
+type juliaPackage struct {
+	UUID        string    `toml:"uuid"`
+	Version     string    `toml:"version"`
+	GitTreeSHA1 string    `toml:"git-tree-sha1"`
+	RepoURL     string    `toml:"repo-url"`
+	RepoRev     string    `toml:"repo-rev"`
+	Path        string    `toml:"path"`
+	Deps        juliaDeps `toml:"deps"`
+}
+
+/*
+juliaDeps maps names of package dependencies to their UUIDs. Dependencies are usually listed by name,
+UUIDs are recorded only when names are ambiguous. E.g.
+
+	deps = ["Dates", "Printf"]
+
+	[deps.CSV.deps]
+	Dates = "ade2ca70-3891-5945-98fb-dc099432e06a"
+*/
+type juliaDeps map[string]string
+
+func (d *juliaDeps) UnmarshalTOML(data any) error {
+	*d = make(juliaDeps)
+	switch deps := data.(type) {
+	case []any:
+		for _, name := range deps {
+			if s, ok := name.(string); ok {
+				(*d)[s] = ""
+			}
+		}
+	case map[string]any:
+		for name, uuid := range deps {
+			s, _ := uuid.(string)
+			(*d)[name] = s
+		}
+	default:
+		return fmt.Errorf("unexpected deps type %T", data)
+	}
+
+	return nil
+}
+
+// stdlib reports whether the package ships with Julia. Those aren't tracked by any source.
+func (p juliaPackage) stdlib() bool {
+	return p.GitTreeSHA1 == "" && p.Path == "" && p.RepoURL == ""
+}
+
+func juliaPURL(name string, p juliaPackage) string {
+	return packageURL("julia", "", name, p.Version, qualifier{key: "uuid", value: p.UUID})
+}
+
+/*
+parseJuliaManifest converts Manifest.toml contents into pkg:julia components & preserves the dependency graph.
+Packages are identified by their UUIDs, which are recorded as Package URL qualifiers. Standard libraries are
+skipped, while tree hashes of package sources are recorded as properties.
+*/
+func parseJuliaManifest(contents []byte) (*cdx.BOM, error) {
+	var manifest juliaManifest
+	if _, err := toml.Decode(string(contents), &manifest); err != nil {
+		return nil, fmt.Errorf("can't parse Manifest.toml: %w", err)
+	}
+	if manifest.ManifestFormat == "" {
+		manifest.Deps = nil
+		if _, err := toml.Decode(string(contents), &manifest.Deps); err != nil {
+			return nil, fmt.Errorf("can't parse Manifest.toml: %w", err)
+		}
+	}
+
+	names := make([]string, 0, len(manifest.Deps))
+	for name := range manifest.Deps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	resolve := func(name, uuid string) (juliaPackage, bool) {
+		for _, p := range manifest.Deps[name] {
+			if (uuid == "" || p.UUID == uuid) && !p.stdlib() {
+				return p, true
+			}
+		}
+
+		return juliaPackage{}, false
+	}
+
+	var (
+		components   []cdx.Component
+		dependencies []cdx.Dependency
+	)
+	for _, name := range names {
+		for _, p := range manifest.Deps[name] {
+			if p.stdlib() {
+				continue
+			}
+
+			purl := juliaPURL(name, p)
+			component := newLibraryComponent(purl, name, p.Version)
+			component.Scope = cdx.ScopeRequired
+			addProperty(&component, "julia:gitTreeSHA1", p.GitTreeSHA1)
+			if p.RepoURL != "" {
+				addProperty(&component, "julia:repoRev", p.RepoRev)
+				addExternalReference(&component, cdx.ERTypeVCS, p.RepoURL)
+			}
+			addExternalReference(&component, cdx.ERTypeOther, p.Path)
+			components = append(components, component)
+
+			dependsOn := make([]string, 0, len(p.Deps))
+			for dependency, uuid := range p.Deps {
+				if d, ok := resolve(dependency, uuid); ok {
+					dependsOn = append(dependsOn, juliaPURL(dependency, d))
+				}
+			}
+			sort.Strings(dependsOn)
+			dependencies = append(dependencies, cdx.Dependency{Ref: purl, Dependencies: &dependsOn})
+		}
+	}
+
+	bom := bomFromComponents(uniqueComponents(components))
+	bom.Dependencies = &dependencies
+	if manifest.JuliaVersion != "" {
+		bom.Properties = &[]cdx.Property{{Name: propertyPrefix + "julia:version", Value: manifest.JuliaVersion}}
+	}
+
+	return bom, nil
+}
@@ -2,7 +2,9 @@ package collectors
 
 import (
 	"context"
+	"os"
 	fp "path/filepath"
+	"strings"
 
 	cdx "github.com/CycloneDX/cyclonedx-go"
 	log "github.com/sirupsen/logrus"
@@ -10,12 +12,17 @@ import (
 )
 
 type JVM struct {
-	executor shellExecutor
+	executor        shellExecutor
+	mavenRepository string // Local Maven repository used to resolve parent POMs
+	// Multi-module sibling indexes keyed by checkout root, so that the checkout is walked once for all of its modules
+	mavenSiblings map[string]map[string]string
 }
 
 func NewJVMCollector() JVM {
 	return JVM{
-		executor: defaultShellExecutor{},
+		executor:        defaultShellExecutor{},
+		mavenRepository: fp.Join(os.Getenv("HOME"), ".m2", "repository"),
+		mavenSiblings:   make(map[string]map[string]string),
 	}
 }
 
@@ -25,25 +32,99 @@ func (j JVM) MatchLanguageFiles(isDir bool, filepath string) bool {
 		return false
 	}
 
-	for _, f := range []string{"pom.xml", "gradlew", "sbt", "build.sbt"} {
+	for _, f := range append([]string{"pom.xml", "gradlew", "sbt", "build.sbt"}, gradleLockfiles...) {
 		if fp.Base(filepath) == f {
 			return true
 		}
 	}
 
-	return false
+	// Version catalogs are only picked up from their default location
+	return fp.Base(filepath) == "libs.versions.toml" && fp.Base(fp.Dir(filepath)) == "gradle"
 }
 
 func (j JVM) String() string {
 	return "jvm collector"
 }
 
-// GenerateBOM implements LanguageCollector interface
+func isBOMEmpty(bom *cdx.BOM) bool {
+	return bom == nil || bom.Components == nil || len(*bom.Components) == 0
+}
+
+/*
+GenerateBOM implements LanguageCollector interface. Maven projects are resolved natively from pom.xml & Gradle
+projects with dependency locking enabled are resolved natively from their lockfiles. Other projects are handed
+to cdxgen, libraries declared in the gradle/libs.versions.toml version catalog are merged into its results.
+*/
 func (j JVM) GenerateBOM(ctx context.Context, bomRoot string) (*cdx.BOM, error) {
-	isBOMEmpty := func(bom *cdx.BOM) bool {
-		return bom == nil || bom.Components == nil || len(*bom.Components) == 0
+	pom := fp.Join(bomRoot, "pom.xml")
+	if _, err := os.Stat(pom); err == nil {
+		checkoutRoot := mavenCheckoutRoot(bomRoot)
+		resolver := &mavenResolver{
+			checkoutRoot: checkoutRoot,
+			repository:   j.mavenRepository,
+			siblings:     j.mavenSiblings[checkoutRoot],
+		}
+		bom, err := resolver.resolve(pom)
+		if j.mavenSiblings != nil && resolver.siblings != nil {
+			j.mavenSiblings[checkoutRoot] = resolver.siblings
+		}
+		if err == nil {
+			return bom, nil
+		}
+		log.WithFields(log.Fields{
+			"collector":       j,
+			"collection path": bomRoot,
+			"error":           err,
+		}).Debug("can't resolve pom.xml natively, falling back to cdxgen")
+	}
+
+	if components, ok := j.parseGradleFiles(bomRoot, gradleLockfiles, parseGradleLockfile); ok {
+		return bomFromComponents(components), nil
+	}
+
+	bom, err := j.bomFromCdxgen(ctx, bomRoot)
+	catalog, ok := j.parseGradleFiles(bomRoot, []string{fp.Join("gradle", "libs.versions.toml")}, parseGradleVersionCatalog)
+	if !ok {
+		return bom, err
+	}
+	if err != nil || isBOMEmpty(bom) {
+		return bomFromComponents(catalog), nil
+	}
+
+	return bomtools.MergeSBOMs(bomtools.MergeSBOMParam{SBOMs: []*cdx.BOM{bom, bomFromComponents(catalog)}})
+}
+
+/*
+parseGradleFiles parses every file given that exists in the bom root. False is returned if none
+of the files could be parsed.
+*/
+func (j JVM) parseGradleFiles(bomRoot string, files []string, parse func([]byte) ([]cdx.Component, error)) ([]cdx.Component, bool) {
+	var components []cdx.Component
+	parsed := false
+
+	for _, f := range files {
+		contents, err := os.ReadFile(fp.Join(bomRoot, f))
+		if err != nil {
+			continue
+		}
+		fileComponents, err := parse(contents)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"collector":       j,
+				"collection path": bomRoot,
+				"error":           err,
+			}).Debugf("can't parse %s natively", f)
+			continue
+		}
+		components = append(components, fileComponents...)
+		parsed = true
 	}
 
+	return uniqueComponents(components), parsed
+}
+
+// bomFromCdxgen collects BOMs with cdxgen in both single & multi module (gradle) modes.
+func (j JVM) bomFromCdxgen(ctx context.Context, bomRoot string) (*cdx.BOM, error) {
 	const language = "jvm"
 	singleModeBom, err := j.executor.bomFromCdxgen(ctx, bomRoot, language, false)
 
@@ -72,13 +153,41 @@ func (j JVM) GenerateBOM(ctx context.Context, bomRoot string) (*cdx.BOM, error)
 	return bomtools.MergeSBOMs(mergedSBOMparam)
 }
 
-// BootstrapLanguageFiles implements LanguageCollector interface
+/*
+BootstrapLanguageFiles implements LanguageCollector interface. Primes the gradle cache with ./gradlew, unless
+dependency locking is enabled - projects with gradle lockfiles don't need a Gradle build. Version catalogs are
+collected from the project directory they belong to.
+*/
 func (j JVM) BootstrapLanguageFiles(ctx context.Context, bomRoots []string) []string {
 	const bootstrapCmd = "./gradlew"
 
-	for dir, files := range SplitPaths(bomRoots) {
+	splitPaths := SplitPaths(bomRoots)
+	isLocked := func(dir string) bool {
+		for d, files := range splitPaths {
+			if d != dir && !strings.HasPrefix(d, dir+string(os.PathSeparator)) {
+				continue
+			}
+			for _, f := range files {
+				for _, lockfile := range gradleLockfiles {
+					if f == lockfile {
+						return true
+					}
+				}
+			}
+		}
+		return false
+	}
+
+	for dir, files := range splitPaths {
 		for _, f := range files {
 			if f == "gradlew" {
+				if isLocked(dir) {
+					log.WithFields(log.Fields{
+						"collector":       j,
+						"collection path": dir,
+					}).Debug("gradle dependency locking is enabled, skipping gradle cache priming")
+					continue
+				}
 				log.WithFields(log.Fields{
 					"collector":       j,
 					"collection path": dir,
@@ -100,5 +209,13 @@ func (j JVM) BootstrapLanguageFiles(ctx context.Context, bomRoots []string) []st
 		}
 	}
 
-	return SquashToDirs(bomRoots)
+	roots := make([]string, 0, len(bomRoots))
+	for _, r := range bomRoots {
+		if fp.Base(r) == "libs.versions.toml" {
+			r = fp.Dir(r) // gradle/libs.versions.toml belongs to the project directory
+		}
+		roots = append(roots, r)
+	}
+
+	return SquashToDirs(roots)
 }
@@ -2,6 +2,8 @@ package collectors
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
 	cdx "github.com/CycloneDX/cyclonedx-go"
@@ -98,9 +100,163 @@ func TestJVMCollector(t *testing.T) {
 		assert.False(t, jvmCollector.MatchLanguageFiles(true, "pom.xml"))
 		assert.False(t, jvmCollector.MatchLanguageFiles(false, "build.gradle"))
 		assert.False(t, jvmCollector.MatchLanguageFiles(false, "build.gradle.kts"))
+
+		for _, f := range []string{"gradle.lockfile", "/opt/app/gradle.lockfile", "settings-gradle.lockfile", "/opt/gradle/libs.versions.toml"} {
+			assert.True(t, jvmCollector.MatchLanguageFiles(false, f))
+		}
+		assert.False(t, jvmCollector.MatchLanguageFiles(false, "/opt/libs.versions.toml"))
 	})
 
 	t.Run("implement Stringer correctly", func(t *testing.T) {
 		assert.Equal(t, "jvm collector", JVM{}.String())
 	})
+	t.Run("resolve Maven modules natively", func(t *testing.T) {
+		executor := new(mockShellExecutor)
+		jvm := JVM{executor: executor, mavenRepository: "../../integration/test/jvm/m2"}
+
+		got, err := jvm.GenerateBOM(context.Background(), "../../integration/test/jvm/maven/service")
+		require.NoError(t, err)
+		executor.AssertNotCalled(t, "bomFromCdxgen")
+
+		components := componentsByPURL(t, got)
+		assert.ElementsMatch(t, []string{
+			"pkg:maven/com.example.app/common@1.4.0",
+			"pkg:maven/com.fasterxml.jackson.core/jackson-databind@2.15.3",
+			"pkg:maven/com.google.guava/guava@32.1.1-jre",
+			"pkg:maven/io.netty/netty-transport-native-epoll@4.1.94.Final?classifier=linux-x86_64",
+			"pkg:maven/org.junit.jupiter/junit-jupiter@5.9.3",
+			"pkg:maven/org.projectlombok/lombok@1.18.28",
+			"pkg:maven/org.slf4j/slf4j-api@2.0.7",
+		}, purls(t, got))
+
+		assert.Equal(t, cdx.ScopeRequired, components["pkg:maven/com.google.guava/guava@32.1.1-jre"].Scope)
+		assert.Equal(t, cdx.ScopeOptional, components["pkg:maven/org.junit.jupiter/junit-jupiter@5.9.3"].Scope)
+		assert.Equal(t, cdx.ScopeExcluded, components["pkg:maven/org.projectlombok/lombok@1.18.28"].Scope)
+		assert.Equal(t, "com.google.guava", components["pkg:maven/com.google.guava/guava@32.1.1-jre"].Group)
+
+		require.NotNil(t, got.Metadata)
+		assert.Equal(t, "pkg:maven/com.example.app/service@1.4.0", got.Metadata.Component.PackageURL)
+	})
+
+	t.Run("resolve parent POMs from multi-module siblings", func(t *testing.T) {
+		resolver := &mavenResolver{checkoutRoot: "../../integration/test/jvm/maven"}
+		got, err := resolver.resolve("../../integration/test/jvm/maven/libs/common/pom.xml")
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []string{
+			"pkg:maven/org.apache.commons/commons-lang3@3.12.0",
+			"pkg:maven/org.slf4j/slf4j-api@2.0.7",
+		}, purls(t, got))
+		assert.Equal(t, "pkg:maven/com.example.app/common@1.4.0", got.Metadata.Component.PackageURL)
+	})
+
+	t.Run("walk multi-module Maven checkouts once", func(t *testing.T) {
+		jvm := JVM{executor: new(mockShellExecutor), mavenSiblings: make(map[string]map[string]string)}
+		root := "../../integration/test/jvm/maven"
+
+		_, err := jvm.GenerateBOM(context.Background(), root+"/service")
+		require.NoError(t, err)
+		require.Contains(t, jvm.mavenSiblings, root)
+		assert.Equal(t, root+"/libs/common/pom.xml", jvm.mavenSiblings[root]["com.example.app:common"])
+
+		// The cached index is reused by other modules of the same checkout instead of walking it again
+		jvm.mavenSiblings[root]["com.example.app:cached"] = "cached/pom.xml"
+		got, err := jvm.GenerateBOM(context.Background(), root+"/libs/common")
+		require.NoError(t, err)
+		assert.Contains(t, purls(t, got), "pkg:maven/org.apache.commons/commons-lang3@3.12.0")
+		assert.Equal(t, "cached/pom.xml", jvm.mavenSiblings[root]["com.example.app:cached"])
+	})
+
+	t.Run("find the root of multi-module Maven projects", func(t *testing.T) {
+		assert.Equal(t, "../../integration/test/jvm/maven",
+			mavenCheckoutRoot("../../integration/test/jvm/maven/libs/common"))
+	})
+
+	t.Run("return an error for malformed POMs", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "pom.xml"), []byte("<project><dependencies>"), 0o644))
+
+		_, err := (&mavenResolver{}).resolve(filepath.Join(dir, "pom.xml"))
+		assert.Error(t, err)
+	})
+	t.Run("don't prime gradle cache when dependency locking is enabled", func(t *testing.T) {
+		executor := new(mockShellExecutor)
+		executor.On("shellOut", "/tmp/unlocked", "./gradlew").Return(nil)
+
+		got := JVM{executor: executor}.BootstrapLanguageFiles(context.Background(), []string{
+			"/tmp/locked/gradlew",
+			"/tmp/locked/settings-gradle.lockfile",
+			"/tmp/locked/app/gradle.lockfile",
+			"/tmp/locked/gradle/libs.versions.toml",
+			"/tmp/unlocked/gradlew",
+		})
+		executor.AssertExpectations(t)
+		executor.AssertNumberOfCalls(t, "shellOut", 1)
+		assert.ElementsMatch(t, []string{"/tmp/locked", "/tmp/locked/app", "/tmp/unlocked"}, got)
+	})
+
+	t.Run("generate BOM natively from gradle lockfiles", func(t *testing.T) {
+		executor := new(mockShellExecutor)
+		jvm := JVM{executor: executor}
+
+		got, err := jvm.GenerateBOM(context.Background(), "../../integration/test/jvm/gradle-locked/app")
+		require.NoError(t, err)
+		executor.AssertNotCalled(t, "bomFromCdxgen")
+
+		components := componentsByPURL(t, got)
+		assert.ElementsMatch(t, []string{
+			"pkg:maven/com.fasterxml.jackson.core/jackson-annotations@2.15.2",
+			"pkg:maven/com.fasterxml.jackson.core/jackson-databind@2.15.2",
+			"pkg:maven/com.google.guava/guava@32.1.1-jre",
+			"pkg:maven/junit/junit@4.13.2",
+			"pkg:maven/org.hamcrest/hamcrest-core@1.3",
+			"pkg:maven/org.projectlombok/lombok@1.18.28",
+		}, purls(t, got))
+		assert.Equal(t, cdx.ScopeRequired, components["pkg:maven/com.google.guava/guava@32.1.1-jre"].Scope)
+		assert.Equal(t, cdx.ScopeOptional, components["pkg:maven/junit/junit@4.13.2"].Scope)
+		assert.Equal(t, cdx.ScopeExcluded, components["pkg:maven/org.projectlombok/lombok@1.18.28"].Scope)
+		assert.Equal(t, &[]cdx.Property{{
+			Name:  "sbomsftw:gradle:configurations",
+			Value: "testCompileClasspath,testRuntimeClasspath",
+		}}, components["pkg:maven/junit/junit@4.13.2"].Properties)
+
+		got, err = jvm.GenerateBOM(context.Background(), "../../integration/test/jvm/gradle-locked")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pkg:maven/com.gradle/gradle-enterprise-gradle-plugin@3.13.4"}, purls(t, got))
+	})
+
+	t.Run("merge version catalog libraries into cdxgen BOMs", func(t *testing.T) {
+		const bomRoot = "../../integration/test/jvm/gradle-catalog"
+		executor := new(mockShellExecutor)
+
+		components := []cdx.Component{{
+			Type:       cdx.ComponentTypeLibrary,
+			Name:       "log4j-core",
+			PackageURL: "pkg:maven/org.apache.logging.log4j/log4j-core@2.20.0",
+		}}
+		bom := new(cdx.BOM)
+		bom.Components = &components
+		executor.On("bomFromCdxgen", bomRoot, "jvm", false).Return(bom, nil)
+		executor.On("bomFromCdxgen", bomRoot, "jvm", true).Return(new(cdx.BOM), nil)
+
+		got, err := JVM{executor: executor}.GenerateBOM(context.Background(), bomRoot)
+		require.NoError(t, err)
+		executor.AssertExpectations(t)
+
+		assert.ElementsMatch(t, []string{
+			"pkg:maven/com.google.guava/guava@32.1.1-jre",
+			"pkg:maven/com.puppycrawl.tools/checkstyle@8.42",
+			"pkg:maven/org.apache.commons/commons-lang3@3.12.0",
+			"pkg:maven/org.apache.logging.log4j/log4j-core@2.20.0",
+			"pkg:maven/org.codehaus.groovy/groovy-json@3.0.5",
+			"pkg:maven/org.codehaus.groovy/groovy@3.0.5",
+		}, purls(t, got))
+	})
+
+	t.Run("return an error for malformed gradle lockfiles", func(t *testing.T) {
+		_, err := parseGradleLockfile([]byte("com.google.guava:guava=compileClasspath"))
+		assert.Error(t, err)
+		_, err = parseGradleVersionCatalog([]byte("[libraries"))
+		assert.Error(t, err)
+	})
 }
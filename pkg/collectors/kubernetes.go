@@ -0,0 +1,111 @@
+package collectors
+
+import (
+	"context"
+	"errors"
+	"os"
+	fp "path/filepath"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	log "github.com/sirupsen/logrus"
+)
+
+// Chart dependency files in the order of preference. Lockfiles hold resolved versions.
+var helmDependencyFiles = []string{"Chart.lock", "requirements.lock", "Chart.yaml", "requirements.yaml"}
+
+var errNoChartsOrImages = errors.New("no chart dependencies or container images found")
+
+// Kubernetes collects Helm chart dependencies & container images deployed to Kubernetes.
+type Kubernetes struct{}
+
+func NewKubernetesCollector() Kubernetes {
+	return Kubernetes{}
+}
+
+// isHelmValuesFile reports whether the file holds chart values. E.g. values.yaml or values-production.yaml
+func isHelmValuesFile(filename string) bool {
+	return strings.HasPrefix(filename, "values") && (fp.Ext(filename) == ".yaml" || fp.Ext(filename) == ".yml")
+}
+
+/*
+MatchLanguageFiles implements LanguageCollector interface. Any YAML file might be a Kubernetes manifest,
+so every YAML file is matched & non-manifests are skipped when generating BOMs. Chart templates aren't
+valid YAML, so those are ignored.
+*/
+func (k Kubernetes) MatchLanguageFiles(isDir bool, filepath string) bool {
+	if isDir {
+		return false
+	}
+	for _, d := range strings.Split(fp.Dir(filepath), string(os.PathSeparator)) {
+		if d == "templates" || d == ".github" || d == "node_modules" {
+			return false
+		}
+	}
+	extension := fp.Ext(filepath)
+
+	return extension == ".yaml" || extension == ".yml" || fp.Base(filepath) == "Chart.lock"
+}
+
+// BootstrapLanguageFiles implements LanguageCollector interface.
+func (k Kubernetes) BootstrapLanguageFiles(_ context.Context, bomRoots []string) []string {
+	return SquashToDirs(bomRoots)
+}
+
+/*
+GenerateBOM implements LanguageCollector interface. Chart dependencies are read from the most precise chart
+dependency file available, while images are read from chart values & Kubernetes manifests in the bom root.
+*/
+func (k Kubernetes) GenerateBOM(_ context.Context, bomRoot string) (*cdx.BOM, error) {
+	var components []cdx.Component
+	skip := func(filename string, err error) {
+		log.WithFields(log.Fields{
+			"collector":       k,
+			"collection path": bomRoot,
+			"error":           err,
+		}).Debugf("can't parse %s", filename)
+	}
+
+	for _, filename := range helmDependencyFiles {
+		contents, err := os.ReadFile(fp.Join(bomRoot, filename))
+		if err != nil {
+			continue
+		}
+		bom, err := parseHelmDependencies(contents)
+		if err != nil {
+			skip(filename, err)
+			continue
+		}
+		components = append(components, *bom.Components...)
+		break
+	}
+
+	entries, _ := os.ReadDir(bomRoot)
+	for _, e := range entries {
+		filename := e.Name()
+		if e.IsDir() || !k.MatchLanguageFiles(false, filename) {
+			continue
+		}
+		contents, err := os.ReadFile(fp.Join(bomRoot, filename))
+		if err != nil {
+			skip(filename, err)
+			continue
+		}
+		images, err := parseImages(contents, isHelmValuesFile(filename))
+		if err != nil {
+			skip(filename, err)
+			continue
+		}
+		components = append(components, images...)
+	}
+	if len(components) == 0 {
+		return nil, errNoChartsOrImages
+	}
+
+	return bomFromComponents(uniqueComponents(components)), nil
+}
+
+// String implements LanguageCollector interface.
+func (k Kubernetes) String() string {
+	return "kubernetes collector"
+}
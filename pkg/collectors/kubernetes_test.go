@@ -0,0 +1,110 @@
+package collectors
+
+import (
+	"context"
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vinted/sbomsftw/pkg/bomtools"
+)
+
+func TestKubernetesCollector(t *testing.T) {
+	t.Run("match correct package files", func(t *testing.T) {
+		kubernetesCollector := Kubernetes{}
+		assert.True(t, kubernetesCollector.MatchLanguageFiles(false, "Chart.yaml"))
+		assert.True(t, kubernetesCollector.MatchLanguageFiles(false, "/opt/charts/checkout/Chart.lock"))
+		assert.True(t, kubernetesCollector.MatchLanguageFiles(false, "/opt/deploy/deployment.yml"))
+		assert.False(t, kubernetesCollector.MatchLanguageFiles(false, "/opt/charts/checkout/templates/deployment.yaml"))
+		assert.False(t, kubernetesCollector.MatchLanguageFiles(false, "/opt/.github/workflows/ci.yml"))
+		assert.False(t, kubernetesCollector.MatchLanguageFiles(false, "/opt/charts/checkout/charts/redis-17.11.3.tgz"))
+		assert.False(t, kubernetesCollector.MatchLanguageFiles(true, "Chart.yaml"))
+	})
+
+	t.Run("bootstrap language files correctly", func(t *testing.T) {
+		got := Kubernetes{}.BootstrapLanguageFiles(context.Background(), []string{
+			"/tmp/some-random-dir/Chart.yaml",
+			"/tmp/some-random-dir/values.yaml",
+			"/tmp/some-random-dir/manifests/deployment.yaml",
+		})
+		assert.ElementsMatch(t, []string{"/tmp/some-random-dir", "/tmp/some-random-dir/manifests"}, got)
+	})
+
+	t.Run("collect chart dependencies & images from chart values", func(t *testing.T) {
+		got, err := Kubernetes{}.GenerateBOM(context.Background(), "../../integration/test/kubernetes/chart")
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []string{
+			"pkg:docker/ghcr.io/acme/checkout@2.3.0",
+			"pkg:docker/prom/statsd-exporter@v0.26.0",
+			"pkg:helm/postgresql@12.5.6?repository_url=https%3A%2F%2Fcharts.bitnami.com%2Fbitnami",
+			"pkg:helm/redis@17.11.3?repository_url=oci%3A%2F%2Fregistry-1.docker.io%2Fbitnamicharts",
+		}, purls(t, got))
+
+		components := componentsByPURL(t, got)
+		postgresql := components["pkg:helm/postgresql@12.5.6?repository_url=https%3A%2F%2Fcharts.bitnami.com%2Fbitnami"]
+		assert.Equal(t, cdx.ScopeRequired, postgresql.Scope)
+		assert.Equal(t, &[]cdx.ExternalReference{
+			{Type: cdx.ERTypeDistribution, URL: "https://charts.bitnami.com/bitnami"},
+		}, postgresql.ExternalReferences)
+		assert.Equal(t, cdx.ComponentTypeContainer, components["pkg:docker/ghcr.io/acme/checkout@2.3.0"].Type)
+	})
+
+	t.Run("tell apart charts from different repositories when merging", func(t *testing.T) {
+		chart, err := Kubernetes{}.GenerateBOM(context.Background(), "../../integration/test/kubernetes/chart")
+		require.NoError(t, err)
+		mirrored, err := parseHelmDependencies([]byte(`
+dependencies:
+  - name: redis
+    version: 17.11.3
+    repository: https://charts.bitnami.com/bitnami
+  - name: postgresql
+    version: 12.5.6
+    repository: https://charts.bitnami.com/bitnami
+`))
+		require.NoError(t, err)
+
+		got, err := bomtools.MergeSBOMs(bomtools.MergeSBOMParam{SBOMs: []*cdx.BOM{chart, mirrored}})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{
+			"pkg:docker/ghcr.io/acme/checkout@2.3.0",
+			"pkg:docker/prom/statsd-exporter@v0.26.0",
+			"pkg:helm/postgresql@12.5.6?repository_url=https%3A%2F%2Fcharts.bitnami.com%2Fbitnami",
+			"pkg:helm/redis@17.11.3?repository_url=https%3A%2F%2Fcharts.bitnami.com%2Fbitnami",
+			"pkg:helm/redis@17.11.3?repository_url=oci%3A%2F%2Fregistry-1.docker.io%2Fbitnamicharts",
+		}, purls(t, got))
+	})
+
+	t.Run("collect images from Kubernetes manifests", func(t *testing.T) {
+		got, err := Kubernetes{}.GenerateBOM(context.Background(), "../../integration/test/kubernetes/manifests")
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []string{
+			"pkg:docker/busybox@1.36",
+			"pkg:docker/nginx@1.25.3",
+			"pkg:docker/quay.io/prometheus/nginx-exporter@0.11.0",
+		}, purls(t, got))
+		assert.Equal(t, &[]cdx.Hash{{
+			Algorithm: cdx.HashAlgoSHA256,
+			Value:     "4c0fdaa8b6341bfdeca5f18f7837462c80cff90527ee35ef185571e1c327beac",
+		}}, componentsByPURL(t, got)["pkg:docker/nginx@1.25.3"].Hashes)
+	})
+
+	t.Run("keep only exact versions of unlocked chart dependencies", func(t *testing.T) {
+		got, err := parseHelmDependencies([]byte("dependencies:\n  - name: redis\n    version: ~17.11.0\n  - name: nats\n    version: 1.1.5\n"))
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"pkg:helm/nats@1.1.5", "pkg:helm/redis"}, purls(t, got))
+	})
+
+	t.Run("return an error when nothing can be collected", func(t *testing.T) {
+		_, err := parseImages([]byte("apiVersion: v1\nkind: Pod\nspec: [\n"), false)
+		assert.Error(t, err)
+		_, err = Kubernetes{}.GenerateBOM(context.Background(), "/tmp/some-random-dir")
+		assert.ErrorIs(t, err, errNoChartsOrImages)
+	})
+
+	t.Run("implement Stringer correctly", func(t *testing.T) {
+		assert.Equal(t, "kubernetes collector", Kubernetes{}.String())
+	})
+}
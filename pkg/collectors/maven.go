@@ -0,0 +1,426 @@
+package collectors
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	fp "path/filepath"
+	"regexp"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	log "github.com/sirupsen/logrus"
+)
+
+// Maximum depth of parent POM & imported BOM chains. Guards against cyclic POM hierarchies
+const maxMavenModelDepth = 16
+
+var mavenPropertyPattern = regexp.MustCompile(`\$\{([^}]+)}`)
+
+// mavenPOM represents the parts of a pom.xml file needed to resolve declared dependencies.
+type mavenPOM struct {
+	Parent struct {
+		GroupID      string  `xml:"groupId"`
+		ArtifactID   string  `xml:"artifactId"`
+		Version      string  `xml:"version"`
+		RelativePath *string `xml:"relativePath"`
+	} `xml:"parent"`
+	GroupID              string        `xml:"groupId"`
+	ArtifactID           string        `xml:"artifactId"`
+	Version              string        `xml:"version"`
+	Properties           xmlProperties `xml:"properties"`
+	DependencyManagement struct {
+		Dependencies []mavenDependency `xml:"dependencies>dependency"`
+	} `xml:"dependencyManagement"`
+	Dependencies []mavenDependency `xml:"dependencies>dependency"`
+}
+
+type mavenDependency struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Version    string `xml:"version"`
+	Type       string `xml:"type"`
+	Classifier string `xml:"classifier"`
+	Scope      string `xml:"scope"`
+}
+
+// key identifies a dependency the same way Maven does when merging dependency declarations.
+func (d mavenDependency) key() string {
+	dependencyType := d.Type
+	if dependencyType == "" {
+		dependencyType = "jar"
+	}
+
+	return strings.Join([]string{d.GroupID, d.ArtifactID, dependencyType, d.Classifier}, ":")
+}
+
+/*
+mavenModel is a POM with its parent POMs applied. Dependencies & managed dependencies are kept
+uninterpolated, because Maven interpolates them only after inheritance - properties of a child POM
+can override the ones used in its parent.
+*/
+type mavenModel struct {
+	groupID, artifactID, version string
+	parentGroupID, parentVersion string
+	properties                   map[string]string
+	managed                      []mavenDependency // Own declarations go before the inherited ones
+	dependencies                 []mavenDependency // Own declarations go before the inherited ones
+}
+
+/*
+property looks up a property used in ${...} expressions. Project model properties (project.version,
+project.parent.groupId, etc.) take precedence over properties declared in <properties>.
+*/
+func (m *mavenModel) property(name string) (string, bool) {
+	for _, prefix := range []string{"project.", "pom."} {
+		switch strings.TrimPrefix(name, prefix) {
+		case "groupId":
+			return m.groupID, true
+		case "artifactId":
+			return m.artifactID, true
+		case "version":
+			return m.version, true
+		case "parent.groupId":
+			return m.parentGroupID, true
+		case "parent.version":
+			return m.parentVersion, true
+		}
+	}
+	if name == "parent.version" {
+		return m.parentVersion, true
+	}
+	value, ok := m.properties[name]
+
+	return value, ok
+}
+
+/*
+interpolate replaces ${...} expressions with property values. Properties referencing other properties are
+resolved recursively, unknown properties are left as is.
+*/
+func (m *mavenModel) interpolate(s string) string {
+	for i := 0; i < maxMavenModelDepth && strings.Contains(s, "${"); i++ {
+		interpolated := mavenPropertyPattern.ReplaceAllStringFunc(s, func(expression string) string {
+			if value, ok := m.property(mavenPropertyPattern.FindStringSubmatch(expression)[1]); ok {
+				return value
+			}
+			return expression
+		})
+		if interpolated == s {
+			break
+		}
+		s = interpolated
+	}
+
+	return strings.TrimSpace(s)
+}
+
+func (m *mavenModel) interpolateDependency(d mavenDependency) mavenDependency {
+	return mavenDependency{
+		GroupID:    m.interpolate(d.GroupID),
+		ArtifactID: m.interpolate(d.ArtifactID),
+		Version:    m.interpolate(d.Version),
+		Type:       m.interpolate(d.Type),
+		Classifier: m.interpolate(d.Classifier),
+		Scope:      m.interpolate(d.Scope),
+	}
+}
+
+/*
+mavenResolver resolves dependencies declared in pom.xml files without running Maven. Parent POMs & imported
+BOMs are looked up in the checkout first (relative paths & multi-module siblings), then in the local Maven
+repository. Only declared dependencies are resolved - transitive dependencies require artifact downloads.
+*/
+type mavenResolver struct {
+	checkoutRoot string // Directory searched for multi-module siblings
+	repository   string // Local Maven repository. E.g. ~/.m2/repository
+
+	siblings map[string]string // groupId:artifactId -> pom.xml path, built on the first lookup unless given
+}
+
+func readMavenPOM(path string) (*mavenPOM, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var pom mavenPOM
+	if err = xml.Unmarshal(contents, &pom); err != nil {
+		return nil, fmt.Errorf("can't parse %s: %w", path, err)
+	}
+
+	return &pom, nil
+}
+
+// coordinates returns groupId:artifactId of a POM, groupId is inherited from the parent if missing.
+func (p *mavenPOM) coordinates() string {
+	groupID := p.GroupID
+	if groupID == "" {
+		groupID = p.Parent.GroupID
+	}
+
+	return groupID + ":" + p.ArtifactID
+}
+
+// findSibling looks up a POM by its coordinates among every pom.xml file in the checkout.
+func (r *mavenResolver) findSibling(coordinates string) (string, bool) {
+	if r.siblings == nil {
+		r.siblings = make(map[string]string)
+		if r.checkoutRoot != "" {
+			_ = fp.WalkDir(r.checkoutRoot, func(path string, d fs.DirEntry, err error) error {
+				if err != nil {
+					return nil
+				}
+				if d.IsDir() && (d.Name() == "target" || d.Name() == "node_modules" || strings.HasPrefix(d.Name(), ".")) &&
+					path != r.checkoutRoot {
+					return fp.SkipDir
+				}
+				if d.IsDir() || d.Name() != "pom.xml" {
+					return nil
+				}
+				if pom, err := readMavenPOM(path); err == nil {
+					if _, exists := r.siblings[pom.coordinates()]; !exists {
+						r.siblings[pom.coordinates()] = path
+					}
+				}
+				return nil
+			})
+		}
+	}
+	path, ok := r.siblings[coordinates]
+
+	return path, ok
+}
+
+/*
+findPOM locates a POM by its coordinates. The path relative to the referencing POM is checked first
+(parent POMs only), then multi-module siblings in the checkout & finally the local Maven repository.
+*/
+func (r *mavenResolver) findPOM(groupID, artifactID, version, relativeTo string) (string, bool) {
+	coordinates := groupID + ":" + artifactID
+
+	if relativeTo != "" {
+		candidate := relativeTo
+		if info, err := os.Stat(candidate); err == nil && info.IsDir() {
+			candidate = fp.Join(candidate, "pom.xml")
+		}
+		if pom, err := readMavenPOM(candidate); err == nil && pom.coordinates() == coordinates {
+			return candidate, true
+		}
+	}
+	if path, ok := r.findSibling(coordinates); ok {
+		return path, true
+	}
+	if r.repository != "" && version != "" {
+		path := fp.Join(r.repository, fp.Join(strings.Split(groupID, ".")...), artifactID, version,
+			artifactID+"-"+version+".pom")
+		if _, err := os.Stat(path); err == nil {
+			return path, true
+		}
+	}
+
+	return "", false
+}
+
+// model builds the inheritance-applied model of the POM at the path given.
+func (r *mavenResolver) model(path string, depth int) (*mavenModel, error) {
+	if depth > maxMavenModelDepth {
+		return nil, errors.New("maximum POM hierarchy depth exceeded")
+	}
+	pom, err := readMavenPOM(path)
+	if err != nil {
+		return nil, err
+	}
+
+	parent := &mavenModel{properties: make(map[string]string)}
+	if pom.Parent.ArtifactID != "" {
+		relativePath := fp.Join(fp.Dir(path), "..", "pom.xml")
+		if pom.Parent.RelativePath != nil {
+			relativePath = ""
+			if *pom.Parent.RelativePath != "" {
+				relativePath = fp.Join(fp.Dir(path), *pom.Parent.RelativePath)
+			}
+		}
+		parentPath, found := r.findPOM(pom.Parent.GroupID, pom.Parent.ArtifactID, pom.Parent.Version, relativePath)
+		if found {
+			if parent, err = r.model(parentPath, depth+1); err != nil {
+				return nil, err
+			}
+		} else {
+			log.WithField("pom", path).Debugf("can't find parent POM %s:%s:%s",
+				pom.Parent.GroupID, pom.Parent.ArtifactID, pom.Parent.Version)
+		}
+	}
+
+	model := &mavenModel{
+		groupID:       pom.GroupID,
+		artifactID:    pom.ArtifactID,
+		version:       pom.Version,
+		parentGroupID: pom.Parent.GroupID,
+		parentVersion: pom.Parent.Version,
+		properties:    make(map[string]string, len(parent.properties)+len(pom.Properties)),
+		managed:       append(pom.DependencyManagement.Dependencies, parent.managed...),
+		dependencies:  append(pom.Dependencies, parent.dependencies...),
+	}
+	if model.groupID == "" {
+		model.groupID = pom.Parent.GroupID
+	}
+	if model.version == "" {
+		model.version = pom.Parent.Version
+	}
+	for k, v := range parent.properties {
+		model.properties[k] = v
+	}
+	for k, v := range pom.Properties {
+		model.properties[k] = v
+	}
+
+	return model, nil
+}
+
+/*
+managedDependencies interpolates dependencyManagement entries of a model, keyed by Maven dependency keys.
+BOMs imported with <scope>import</scope> are resolved recursively & have a lower precedence than
+dependencies managed directly.
+*/
+func (r *mavenResolver) managedDependencies(model *mavenModel, depth int) map[string]mavenDependency {
+	managed := make(map[string]mavenDependency)
+	var imports []mavenDependency
+
+	for _, d := range model.managed {
+		d = model.interpolateDependency(d)
+		if d.Scope == "import" && d.Type == "pom" {
+			imports = append(imports, d)
+			continue
+		}
+		if _, exists := managed[d.key()]; !exists {
+			managed[d.key()] = d
+		}
+	}
+
+	for _, i := range imports {
+		path, found := r.findPOM(i.GroupID, i.ArtifactID, i.Version, "")
+		if !found || depth >= maxMavenModelDepth {
+			log.Debugf("can't find imported BOM %s:%s:%s", i.GroupID, i.ArtifactID, i.Version)
+			continue
+		}
+		imported, err := r.model(path, depth+1)
+		if err != nil {
+			log.WithError(err).Debugf("can't resolve imported BOM %s", path)
+			continue
+		}
+		for key, d := range r.managedDependencies(imported, depth+1) {
+			if _, exists := managed[key]; !exists {
+				managed[key] = d
+			}
+		}
+	}
+
+	return managed
+}
+
+/*
+mavenScope maps Maven dependency scopes to CycloneDX scopes: test dependencies are optional,
+provided & system dependencies are supplied by the runtime environment, so they are excluded.
+*/
+func mavenScope(scope string) cdx.Scope {
+	switch scope {
+	case "test":
+		return cdx.ScopeOptional
+	case "provided", "system":
+		return cdx.ScopeExcluded
+	default:
+		return cdx.ScopeRequired
+	}
+}
+
+func mavenPURL(d mavenDependency) string {
+	dependencyType := d.Type
+	if dependencyType == "jar" {
+		dependencyType = ""
+	}
+
+	return packageURL("maven", d.GroupID, d.ArtifactID, d.Version,
+		qualifier{key: "classifier", value: d.Classifier},
+		qualifier{key: "type", value: dependencyType},
+	)
+}
+
+/*
+resolve converts dependencies declared in the pom.xml file at the path given into pkg:maven components.
+Versions & scopes missing from dependency declarations are taken from dependencyManagement. Dependencies
+with versions that can't be resolved are skipped.
+*/
+func (r *mavenResolver) resolve(path string) (*cdx.BOM, error) {
+	model, err := r.model(path, 0)
+	if err != nil {
+		return nil, err
+	}
+	managed := r.managedDependencies(model, 0)
+
+	seen := make(map[string]bool)
+	components := make([]cdx.Component, 0, len(model.dependencies))
+	for _, d := range model.dependencies {
+		d = model.interpolateDependency(d)
+		if seen[d.key()] {
+			continue
+		}
+		seen[d.key()] = true
+
+		if m, ok := managed[d.key()]; ok {
+			if d.Version == "" {
+				d.Version = m.Version
+			}
+			if d.Scope == "" {
+				d.Scope = m.Scope
+			}
+		}
+		if d.Version == "" || strings.Contains(d.Version, "${") {
+			log.WithField("pom", path).Debugf("can't resolve version of %s:%s", d.GroupID, d.ArtifactID)
+			continue
+		}
+
+		component := newLibraryComponent(mavenPURL(d), d.ArtifactID, d.Version)
+		component.Group = d.GroupID
+		component.Scope = mavenScope(d.Scope)
+		components = append(components, component)
+	}
+
+	bom := bomFromComponents(components)
+	if model.groupID != "" && model.artifactID != "" {
+		project := mavenDependency{
+			GroupID:    model.interpolate(model.groupID),
+			ArtifactID: model.interpolate(model.artifactID),
+			Version:    model.interpolate(model.version),
+		}
+		bom.Metadata = &cdx.Metadata{Component: &cdx.Component{
+			BOMRef:     mavenPURL(project),
+			Type:       cdx.ComponentTypeApplication,
+			Group:      project.GroupID,
+			Name:       project.ArtifactID,
+			Version:    project.Version,
+			PackageURL: mavenPURL(project),
+		}}
+	}
+
+	return bom, nil
+}
+
+/*
+mavenCheckoutRoot returns the top-most directory containing a pom.xml file when walking up from the directory
+given. Walking stops at the root of the git checkout, so multi-module siblings are looked up in the checkout only.
+*/
+func mavenCheckoutRoot(dir string) string {
+	root := dir
+	for current := dir; ; current = fp.Dir(current) {
+		if _, err := os.Stat(fp.Join(current, "pom.xml")); err == nil {
+			root = current
+		}
+		if _, err := os.Stat(fp.Join(current, ".git")); err == nil || current == fp.Dir(current) {
+			break
+		}
+	}
+
+	return root
+}
@@ -0,0 +1,132 @@
+package collectors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+// nixFlakeLock represents flake.lock files, written by nix flake lock.
+type nixFlakeLock struct {
+	Version int                     `json:"version"`
+	Root    string                  `json:"root"`
+	Nodes   map[string]nixFlakeNode `json:"nodes"`
+}
+
+type nixFlakeNode struct {
+	Locked   *nixFlakeRef `json:"locked"`
+	Original *nixFlakeRef `json:"original"`
+}
+
+// nixFlakeRef is a flake reference. Attributes depend on its type. E.g. github, git or tarball
+type nixFlakeRef struct {
+	Type    string `json:"type"`
+	Owner   string `json:"owner"`
+	Repo    string `json:"repo"`
+	Host    string `json:"host"`
+	URL     string `json:"url"`
+	Ref     string `json:"ref"`
+	Rev     string `json:"rev"`
+	NarHash string `json:"narHash"`
+}
+
+/*
+remoteURL returns the git remote URL of flake references hosted on git forges & in git repositories.
+An empty string is returned for other types of flake references.
+*/
+func (r nixFlakeRef) remoteURL() string {
+	defaultHosts := map[string]string{"github": "github.com", "gitlab": "gitlab.com", "sourcehut": "git.sr.ht"}
+
+	switch r.Type {
+	case "github", "gitlab", "sourcehut":
+		host := r.Host
+		if host == "" {
+			host = defaultHosts[r.Type]
+		}
+		owner := r.Owner
+		if r.Type == "sourcehut" && !strings.HasPrefix(owner, "~") {
+			owner = "~" + owner
+		}
+
+		return "https://" + host + "/" + owner + "/" + r.Repo
+	case "git":
+		return strings.TrimPrefix(r.URL, "git+")
+	}
+
+	return ""
+}
+
+/*
+nixArchiveComponent converts a flake input fetched as an archive or a file into a pkg:generic component
+namespaced by its hostname. The download URL is recorded as a distribution reference.
+*/
+func nixArchiveComponent(input, archiveURL string) (cdx.Component, bool) {
+	parsed, err := url.Parse(archiveURL)
+	if err != nil || parsed.Host == "" {
+		return cdx.Component{}, false
+	}
+	dir, name := path.Split(strings.TrimSuffix(parsed.Path, "/"))
+	if name == "" {
+		name = input
+	}
+
+	component := newLibraryComponent(packageURL("generic", parsed.Host+dir, name, ""), name, "")
+	component.Scope = cdx.ScopeRequired
+	addExternalReference(&component, cdx.ERTypeDistribution, archiveURL)
+
+	return component, true
+}
+
+/*
+parseFlakeLock converts flake.lock contents into components pinned to their locked revisions. Inputs hosted
+on git forges & in git repositories are converted the same way as git submodules, while tarball & file inputs
+become pkg:generic components. NAR hashes of locked inputs are recorded as properties. Path & indirect
+inputs don't pin anything, so those are skipped.
+*/
+func parseFlakeLock(contents []byte) (*cdx.BOM, error) {
+	var lockfile nixFlakeLock
+	if err := json.Unmarshal(contents, &lockfile); err != nil {
+		return nil, fmt.Errorf("can't parse flake.lock: %w", err)
+	}
+	if len(lockfile.Nodes) == 0 {
+		return nil, errors.New("can't parse flake.lock: no nodes found")
+	}
+
+	components := make([]cdx.Component, 0, len(lockfile.Nodes))
+	for _, input := range sortedKeys(lockfile.Nodes) {
+		node := lockfile.Nodes[input]
+		if input == lockfile.Root || node.Locked == nil {
+			continue
+		}
+		locked := *node.Locked
+
+		var component cdx.Component
+		switch locked.Type {
+		case "github", "gitlab", "sourcehut", "git":
+			component = gitComponent(locked.remoteURL(), input, locked.Rev)
+		case "tarball", "file":
+			c, ok := nixArchiveComponent(input, locked.URL)
+			if !ok {
+				continue
+			}
+			component = c
+		default:
+			continue
+		}
+
+		// NAR hashes cover the serialized store path, not the fetched archive, so they can't be used as component hashes
+		addProperty(&component, "nix:narHash", locked.NarHash)
+		addProperty(&component, "nix:input", input)
+		if node.Original != nil {
+			addProperty(&component, "nix:ref", node.Original.Ref)
+		}
+		components = append(components, component)
+	}
+
+	return bomFromComponents(uniqueComponents(components)), nil
+}
@@ -0,0 +1,148 @@
+package collectors
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+// npmLockfile represents package-lock.json & npm-shrinkwrap.json files, every lockfile version included.
+type npmLockfile struct {
+	LockfileVersion int                          `json:"lockfileVersion"`
+	Packages        map[string]npmLockedPackage  `json:"packages"`     // lockfileVersion 2 & 3
+	Dependencies    map[string]npmLockDependency `json:"dependencies"` // lockfileVersion 1 & 2
+}
+
+type npmLockedPackage struct {
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	Resolved    string `json:"resolved"`
+	Integrity   string `json:"integrity"`
+	Link        bool   `json:"link"`
+	Dev         bool   `json:"dev"`
+	Optional    bool   `json:"optional"`
+	DevOptional bool   `json:"devOptional"`
+}
+
+type npmLockDependency struct {
+	Version      string                       `json:"version"`
+	Resolved     string                       `json:"resolved"`
+	Integrity    string                       `json:"integrity"`
+	Dev          bool                         `json:"dev"`
+	Optional     bool                         `json:"optional"`
+	Dependencies map[string]npmLockDependency `json:"dependencies"`
+}
+
+// npmPURL formats a pkg:npm Package URL. Scoped package names are split into namespace & name.
+func npmPURL(packageName, version string) string {
+	namespace, name := "", packageName
+	if strings.HasPrefix(packageName, "@") {
+		if scope, scopedName, ok := strings.Cut(packageName, "/"); ok {
+			namespace, name = scope, scopedName
+		}
+	}
+
+	return packageURL("npm", namespace, name, version)
+}
+
+/*
+npmComponent creates a pkg:npm component. Versions are inspected for npm specific protocols:
+aliases (npm:real-name@1.0.0) are resolved to the real package name, git dependencies use the commit as
+their version & local file/link dependencies are skipped altogether - false is returned for those.
+*/
+func npmComponent(name, version, resolved, integrity string, dev, optional bool) (cdx.Component, bool) {
+	switch {
+	case version == "" || strings.HasPrefix(version, "file:") || strings.HasPrefix(version, "link:"):
+		return cdx.Component{}, false
+	case strings.HasPrefix(version, "npm:"):
+		alias := strings.TrimPrefix(version, "npm:")
+		if i := strings.LastIndex(alias, "@"); i > 0 {
+			name, version = alias[:i], alias[i+1:]
+		}
+	case strings.Contains(version, "://") || strings.HasPrefix(version, "github:"):
+		if resolved == "" {
+			resolved = version
+		}
+		if _, commit, ok := strings.Cut(version, "#"); ok {
+			version = commit
+		}
+	}
+
+	component := newLibraryComponent(npmPURL(name, version), name, version)
+	if dev || optional {
+		component.Scope = cdx.ScopeOptional
+	} else {
+		component.Scope = cdx.ScopeRequired
+	}
+	addHashes(&component, hashesFromSRI(integrity)...)
+
+	refType := cdx.ERTypeDistribution
+	if strings.HasPrefix(resolved, "git") {
+		refType = cdx.ERTypeVCS
+	}
+	addExternalReference(&component, refType, resolved)
+
+	return component, true
+}
+
+/*
+npmPackageName extracts a package name from a nested node_modules path. E.g. given the following input:
+
+	node_modules/@babel/core/node_modules/semver
+
+this function will return: semver
+*/
+func npmPackageName(path string) string {
+	const nodeModules = "node_modules/"
+	if i := strings.LastIndex(path, nodeModules); i != -1 {
+		return path[i+len(nodeModules):]
+	}
+
+	return ""
+}
+
+/*
+parseNPMLockfile converts package-lock.json & npm-shrinkwrap.json contents into pkg:npm components.
+Lockfile version 1 stores a tree of dependencies while versions 2 & 3 store a flat map of node_modules paths.
+Version 2 contains both - the packages map is preferred, because it's more accurate.
+*/
+func parseNPMLockfile(contents []byte) ([]cdx.Component, error) {
+	var lockfile npmLockfile
+	if err := json.Unmarshal(contents, &lockfile); err != nil {
+		return nil, fmt.Errorf("can't parse npm lockfile: %w", err)
+	}
+
+	var components []cdx.Component
+
+	if len(lockfile.Packages) > 0 {
+		for path, p := range lockfile.Packages {
+			name := npmPackageName(path)
+			if name == "" || p.Link { // Root project, workspace sources & symlinks to them
+				continue
+			}
+			if p.Name != "" { // Aliased packages store the real package name
+				name = p.Name
+			}
+			if c, ok := npmComponent(name, p.Version, p.Resolved, p.Integrity, p.Dev || p.DevOptional, p.Optional); ok {
+				components = append(components, c)
+			}
+		}
+
+		return uniqueComponents(components), nil
+	}
+
+	var walk func(dependencies map[string]npmLockDependency)
+	walk = func(dependencies map[string]npmLockDependency) {
+		for name, d := range dependencies {
+			if c, ok := npmComponent(name, d.Version, d.Resolved, d.Integrity, d.Dev, d.Optional); ok {
+				components = append(components, c)
+			}
+			walk(d.Dependencies)
+		}
+	}
+	walk(lockfile.Dependencies)
+
+	return uniqueComponents(components), nil
+}
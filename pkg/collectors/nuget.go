@@ -0,0 +1,301 @@
+package collectors
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+var (
+	// MSBuild conditions restricting items to a target framework. E.g. '$(TargetFramework)' == 'net6.0'
+	targetFrameworkConditionPattern = regexp.MustCompile(`'\$\(TargetFramework\)'\s*==\s*'([^']+)'`)
+	// MSBuild property references. E.g. $(SerilogVersion)
+	msbuildPropertyPattern = regexp.MustCompile(`\$\(([^)]+)\)`)
+)
+
+// nugetLockfile represents packages.lock.json files, keyed by target frameworks & runtime identifiers.
+type nugetLockfile struct {
+	Dependencies map[string]map[string]struct {
+		Type        string `json:"type"`
+		Resolved    string `json:"resolved"`
+		ContentHash string `json:"contentHash"`
+	} `json:"dependencies"`
+}
+
+// msbuildProject represents the parts of *.csproj, *.fsproj & Directory.Packages.props files needed to find packages.
+type msbuildProject struct {
+	PropertyGroups []xmlProperties `xml:"PropertyGroup"`
+	ItemGroups     []struct {
+		Condition               string                    `xml:"Condition,attr"`
+		PackageReferences       []msbuildPackageReference `xml:"PackageReference"`
+		PackageVersions         []msbuildPackageReference `xml:"PackageVersion"`
+		GlobalPackageReferences []msbuildPackageReference `xml:"GlobalPackageReference"`
+	} `xml:"ItemGroup"`
+}
+
+type msbuildPackageReference struct {
+	Include              string `xml:"Include,attr"`
+	Condition            string `xml:"Condition,attr"`
+	Version              string `xml:"Version,attr"`
+	VersionElement       string `xml:"Version"`
+	VersionOverride      string `xml:"VersionOverride,attr"`
+	PrivateAssets        string `xml:"PrivateAssets,attr"`
+	PrivateAssetsElement string `xml:"PrivateAssets"`
+}
+
+func (r msbuildPackageReference) version() string {
+	for _, v := range []string{r.VersionOverride, r.Version, r.VersionElement} {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
+
+// isDevelopmentDependency reports whether the package is used only at build time. E.g. analyzers
+func (r msbuildPackageReference) isDevelopmentDependency() bool {
+	return strings.EqualFold(r.PrivateAssets, "all") || strings.EqualFold(strings.TrimSpace(r.PrivateAssetsElement), "all")
+}
+
+// packagesConfig represents legacy packages.config files.
+type packagesConfig struct {
+	Packages []struct {
+		ID                    string `xml:"id,attr"`
+		Version               string `xml:"version,attr"`
+		TargetFramework       string `xml:"targetFramework,attr"`
+		DevelopmentDependency bool   `xml:"developmentDependency,attr"`
+	} `xml:"package"`
+}
+
+/*
+nugetVersion pins a NuGet version. Exact version ranges are unwrapped, while floating versions
+& open ranges can't be pinned, so an empty string is returned for those. E.g. given the following input:
+
+	[13.0.3]
+
+this function will return: 13.0.3. NuGet resolves the lowest applicable version, so minimum
+versions such as 13.0.3 are returned as is.
+*/
+func nugetVersion(version string) string {
+	version = strings.TrimSpace(version)
+	if strings.HasPrefix(version, "[") && strings.HasSuffix(version, "]") && !strings.Contains(version, ",") {
+		version = strings.TrimSpace(version[1 : len(version)-1])
+	}
+	if strings.ContainsAny(version, "[]()*,$") {
+		return ""
+	}
+
+	return version
+}
+
+/*
+nugetComponent creates a pkg:nuget component. Target frameworks the package is used by are recorded as
+properties. Development dependencies are optional.
+*/
+func nugetComponent(id, version string, targetFrameworks []string, development bool) cdx.Component {
+	component := newLibraryComponent(packageURL("nuget", "", id, version), id, version)
+	component.Scope = cdx.ScopeRequired
+	if development {
+		component.Scope = cdx.ScopeOptional
+	}
+	for _, tfm := range targetFrameworks {
+		addProperty(&component, "nuget:targetFramework", tfm)
+	}
+
+	return component
+}
+
+/*
+mergeNuGetComponents squashes components with duplicate Package URLs. Unlike uniqueComponents,
+target framework properties of every duplicate are kept.
+*/
+func mergeNuGetComponents(components []cdx.Component) []cdx.Component {
+	merged := uniqueComponents(components)
+	indexes := make(map[string]int, len(merged))
+	for i, c := range merged {
+		indexes[c.PackageURL] = i
+		merged[i].Properties = nil
+	}
+
+	seen := make(map[string]bool)
+	for _, c := range components {
+		if c.Properties == nil {
+			continue
+		}
+		for _, p := range *c.Properties {
+			key := c.PackageURL + "|" + p.Name + "|" + p.Value
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			target := &merged[indexes[c.PackageURL]]
+			if target.Properties == nil {
+				target.Properties = &[]cdx.Property{}
+			}
+			*target.Properties = append(*target.Properties, p)
+		}
+	}
+	for i := range merged {
+		if merged[i].Properties != nil {
+			properties := *merged[i].Properties
+			sort.SliceStable(properties, func(a, b int) bool { return properties[a].Value < properties[b].Value })
+		}
+	}
+
+	return merged
+}
+
+/*
+parseNuGetLockfile converts packages.lock.json contents into pkg:nuget components. Direct & transitive
+packages of every target framework are collected, project references are skipped. Content hashes
+are recorded as SHA-512 hashes.
+*/
+func parseNuGetLockfile(contents []byte) ([]cdx.Component, error) {
+	var lockfile nugetLockfile
+	if err := json.Unmarshal(contents, &lockfile); err != nil {
+		return nil, fmt.Errorf("can't parse packages.lock.json: %w", err)
+	}
+
+	targets := make([]string, 0, len(lockfile.Dependencies))
+	for target := range lockfile.Dependencies {
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+
+	var components []cdx.Component
+	for _, target := range targets {
+		tfm, _, _ := strings.Cut(target, "/") // Runtime specific targets. E.g. net6.0/linux-x64
+		packages := lockfile.Dependencies[target]
+		ids := make([]string, 0, len(packages))
+		for id := range packages {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+
+		for _, id := range ids {
+			p := packages[id]
+			if strings.EqualFold(p.Type, "Project") {
+				continue
+			}
+			component := nugetComponent(id, p.Resolved, []string{tfm}, false)
+			if p.ContentHash != "" {
+				addHashes(&component, hashesFromSRI("sha512-"+p.ContentHash)...)
+			}
+			components = append(components, component)
+		}
+	}
+
+	return mergeNuGetComponents(components), nil
+}
+
+// properties merges every PropertyGroup of a project. Later declarations win.
+func (p msbuildProject) properties() map[string]string {
+	properties := make(map[string]string)
+	for _, group := range p.PropertyGroups {
+		for k, v := range group {
+			properties[k] = v
+		}
+	}
+
+	return properties
+}
+
+/*
+parseCentralPackageVersions parses Directory.Packages.props files used for central package management.
+Versions of PackageVersion items are returned keyed by lower-cased package IDs, GlobalPackageReference
+items apply to every project, so they are returned as components.
+*/
+func parseCentralPackageVersions(contents []byte) (map[string]string, []cdx.Component, error) {
+	var props msbuildProject
+	if err := xml.Unmarshal(contents, &props); err != nil {
+		return nil, nil, fmt.Errorf("can't parse Directory.Packages.props: %w", err)
+	}
+	properties := props.properties()
+
+	versions := make(map[string]string)
+	var global []cdx.Component
+	for _, group := range props.ItemGroups {
+		for _, r := range group.PackageVersions {
+			versions[strings.ToLower(r.Include)] = interpolateMSBuild(r.version(), properties)
+		}
+		for _, r := range group.GlobalPackageReferences {
+			// Global package references are meant for build tooling (analyzers, versioning), just like development dependencies
+			global = append(global, nugetComponent(r.Include, nugetVersion(interpolateMSBuild(r.version(), properties)), nil, true))
+		}
+	}
+
+	return versions, global, nil
+}
+
+// interpolateMSBuild replaces $(Property) references with project property values.
+func interpolateMSBuild(s string, properties map[string]string) string {
+	return msbuildPropertyPattern.ReplaceAllStringFunc(s, func(reference string) string {
+		if value, ok := properties[msbuildPropertyPattern.FindStringSubmatch(reference)[1]]; ok {
+			return value
+		}
+		return reference
+	})
+}
+
+/*
+parseMSBuildProject converts PackageReference items of *.csproj & *.fsproj files into pkg:nuget components.
+Packages without a version are looked up in centrally managed versions. Packages are attributed to the
+target frameworks of the project, unless their item or item group is conditioned on a single target framework.
+*/
+func parseMSBuildProject(contents []byte, centralVersions map[string]string) ([]cdx.Component, error) {
+	var project msbuildProject
+	if err := xml.Unmarshal(contents, &project); err != nil {
+		return nil, fmt.Errorf("can't parse MSBuild project: %w", err)
+	}
+	properties := project.properties()
+
+	var targetFrameworks []string
+	for _, tfm := range strings.Split(properties["TargetFrameworks"]+";"+properties["TargetFramework"], ";") {
+		if tfm = strings.TrimSpace(tfm); tfm != "" {
+			targetFrameworks = append(targetFrameworks, tfm)
+		}
+	}
+
+	var components []cdx.Component
+	for _, group := range project.ItemGroups {
+		for _, r := range group.PackageReferences {
+			if r.Include == "" {
+				continue // PackageReference Update items only modify existing references
+			}
+			version := r.version()
+			if version == "" {
+				version = centralVersions[strings.ToLower(r.Include)]
+			}
+			version = nugetVersion(interpolateMSBuild(version, properties))
+
+			frameworks := targetFrameworks
+			if m := targetFrameworkConditionPattern.FindStringSubmatch(r.Condition + group.Condition); m != nil {
+				frameworks = []string{m[1]}
+			}
+			components = append(components, nugetComponent(r.Include, version, frameworks, r.isDevelopmentDependency()))
+		}
+	}
+
+	return mergeNuGetComponents(components), nil
+}
+
+// parsePackagesConfig converts legacy packages.config contents into pkg:nuget components.
+func parsePackagesConfig(contents []byte) ([]cdx.Component, error) {
+	var config packagesConfig
+	if err := xml.Unmarshal(contents, &config); err != nil {
+		return nil, fmt.Errorf("can't parse packages.config: %w", err)
+	}
+
+	components := make([]cdx.Component, 0, len(config.Packages))
+	for _, p := range config.Packages {
+		components = append(components, nugetComponent(p.ID, p.Version, []string{p.TargetFramework}, p.DevelopmentDependency))
+	}
+
+	return mergeNuGetComponents(components), nil
+}
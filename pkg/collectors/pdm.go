@@ -0,0 +1,82 @@
+package collectors
+
+import (
+	"fmt"
+
+	"github.com/BurntSushi/toml"
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+// pdmLockfile represents pdm.lock files, written by pdm lock.
+type pdmLockfile struct {
+	Packages []pdmPackage `toml:"package"`
+}
+
+type pdmPackage struct {
+	Name     string   `toml:"name"`
+	Version  string   `toml:"version"`
+	Groups   []string `toml:"groups"` // Lockfile version 4.4 & newer
+	Git      string   `toml:"git"`
+	Revision string   `toml:"revision"`
+	URL      string   `toml:"url"`
+	Path     string   `toml:"path"`
+	Editable bool     `toml:"editable"`
+	Files    []struct {
+		Hash string `toml:"hash"`
+	} `toml:"files"`
+}
+
+/*
+isOptional reports whether the package belongs only to dependency groups other than default, e.g. dev or test.
+Older lockfiles don't record groups, packages of those are required.
+*/
+func (p pdmPackage) isOptional() bool {
+	if len(p.Groups) == 0 {
+		return false
+	}
+	for _, g := range p.Groups {
+		if g == "default" {
+			return false
+		}
+	}
+
+	return true
+}
+
+/*
+parsePdmLockfile converts pdm.lock contents into pkg:pypi components. Packages of the default group are required,
+packages of other dependency groups are optional. Local & editable packages are skipped.
+*/
+func parsePdmLockfile(contents []byte) (*cdx.BOM, error) {
+	var lockfile pdmLockfile
+	if _, err := toml.Decode(string(contents), &lockfile); err != nil {
+		return nil, fmt.Errorf("can't parse pdm.lock: %w", err)
+	}
+
+	components := make([]cdx.Component, 0, len(lockfile.Packages))
+	for _, p := range lockfile.Packages {
+		if p.Path != "" || p.Editable {
+			continue
+		}
+
+		component := newLibraryComponent(pypiPURL(p.Name, p.Version), p.Name, p.Version)
+		component.Scope = cdx.ScopeRequired
+		if p.isOptional() {
+			component.Scope = cdx.ScopeOptional
+		}
+
+		digests := make([]string, 0, len(p.Files))
+		for _, f := range p.Files {
+			digests = append(digests, f.Hash)
+		}
+		addHashes(&component, hashesFromPyPIDigests(digests)...)
+
+		if p.Git != "" {
+			addExternalReference(&component, cdx.ERTypeVCS, p.Git+"#"+p.Revision)
+		}
+		addExternalReference(&component, cdx.ERTypeDistribution, p.URL)
+		components = append(components, component)
+	}
+
+	return bomFromComponents(uniqueComponents(components)), nil
+}
@@ -0,0 +1,68 @@
+package collectors
+
+import (
+	"context"
+	"os"
+	fp "path/filepath"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	log "github.com/sirupsen/logrus"
+)
+
+type PHP struct {
+	executor shellExecutor
+}
+
+func NewPHPCollector() PHP {
+	return PHP{
+		executor: defaultShellExecutor{},
+	}
+}
+
+// MatchLanguageFiles implements LanguageCollector interface.
+func (p PHP) MatchLanguageFiles(isDir bool, filepath string) bool {
+	if isDir {
+		return false
+	}
+	for _, d := range strings.Split(fp.Dir(filepath), string(os.PathSeparator)) {
+		if d == "vendor" { // Ignore files of installed packages
+			return false
+		}
+	}
+	filename := fp.Base(filepath)
+
+	return filename == "composer.json" || filename == "composer.lock"
+}
+
+// BootstrapLanguageFiles implements LanguageCollector interface.
+func (p PHP) BootstrapLanguageFiles(_ context.Context, bomRoots []string) []string {
+	return SquashToDirs(bomRoots)
+}
+
+/*
+GenerateBOM implements LanguageCollector interface. composer.lock is parsed natively. Falls back
+to cdxgen when the lockfile is missing or can't be parsed.
+*/
+func (p PHP) GenerateBOM(ctx context.Context, bomRoot string) (*cdx.BOM, error) {
+	const language = "php"
+
+	if contents, err := os.ReadFile(fp.Join(bomRoot, "composer.lock")); err == nil {
+		bom, err := parseComposerLockfile(contents)
+		if err == nil {
+			return bom, nil
+		}
+		log.WithFields(log.Fields{
+			"collector":       p,
+			"collection path": bomRoot,
+			"error":           err,
+		}).Debug("can't parse composer.lock natively, falling back to cdxgen")
+	}
+
+	return p.executor.bomFromCdxgen(ctx, bomRoot, language, false)
+}
+
+// String implements LanguageCollector interface.
+func (p PHP) String() string {
+	return "php collector"
+}
@@ -0,0 +1,76 @@
+package collectors
+
+import (
+	"context"
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPHPCollector(t *testing.T) {
+	t.Run("match correct package files", func(t *testing.T) {
+		phpCollector := PHP{}
+		assert.True(t, phpCollector.MatchLanguageFiles(false, "composer.json"))
+		assert.True(t, phpCollector.MatchLanguageFiles(false, "/opt/composer.lock"))
+		assert.False(t, phpCollector.MatchLanguageFiles(false, "/opt/vendor/laravel/framework/composer.json"))
+		assert.False(t, phpCollector.MatchLanguageFiles(true, "composer.json"))
+		assert.False(t, phpCollector.MatchLanguageFiles(false, "/etc/passwd"))
+	})
+
+	t.Run("bootstrap language files correctly", func(t *testing.T) {
+		got := PHP{}.BootstrapLanguageFiles(context.Background(), []string{
+			"/tmp/some-random-dir/composer.json",
+			"/tmp/some-random-dir/composer.lock",
+			"/tmp/some-random-dir/inner-dir/composer.json",
+		})
+		assert.ElementsMatch(t, []string{"/tmp/some-random-dir", "/tmp/some-random-dir/inner-dir"}, got)
+	})
+
+	t.Run("fall back to cdxgen when composer.lock is missing", func(t *testing.T) {
+		const bomRoot = "/tmp/some-random-dir"
+		executor := new(mockShellExecutor)
+		executor.On("bomFromCdxgen", bomRoot, "php", false).Return(new(cdx.BOM), nil)
+		_, _ = PHP{executor: executor}.GenerateBOM(context.Background(), bomRoot)
+		executor.AssertExpectations(t)
+	})
+
+	t.Run("generate BOM natively from composer.lock", func(t *testing.T) {
+		executor := new(mockShellExecutor)
+		got, err := PHP{executor: executor}.GenerateBOM(context.Background(), "../../integration/test/php")
+		require.NoError(t, err)
+		executor.AssertNotCalled(t, "bomFromCdxgen")
+
+		assert.ElementsMatch(t, []string{
+			"pkg:composer/laravel/framework@v10.13.0",
+			"pkg:composer/monolog/monolog@3.3.1",
+			"pkg:composer/phpunit/phpunit@10.2.2",
+		}, purls(t, got))
+
+		components := componentsByPURL(t, got)
+		assert.Equal(t, cdx.ScopeRequired, components["pkg:composer/laravel/framework@v10.13.0"].Scope)
+		assert.Equal(t, cdx.ScopeOptional, components["pkg:composer/phpunit/phpunit@10.2.2"].Scope)
+
+		monolog := components["pkg:composer/monolog/monolog@3.3.1"]
+		assert.Equal(t, "monolog", monolog.Group)
+		assert.Equal(t, &[]cdx.Hash{{
+			Algorithm: cdx.HashAlgoSHA1,
+			Value:     "3c1e2a4b5d6f7089a1b2c3d4e5f60718293a4b5c",
+		}}, monolog.Hashes)
+		assert.Equal(t, &[]cdx.ExternalReference{
+			{Type: cdx.ERTypeVCS, URL: "https://github.com/Seldaek/monolog.git#9b5daeaffce5b926cac47923798bba91059e60e2"},
+			{Type: cdx.ERTypeDistribution, URL: "https://private.repo.example.com/dist/monolog/monolog/3.3.1.zip"},
+		}, monolog.ExternalReferences)
+		assert.Nil(t, components["pkg:composer/laravel/framework@v10.13.0"].Hashes)
+	})
+
+	t.Run("return an error for malformed composer.lock", func(t *testing.T) {
+		_, err := parseComposerLockfile([]byte(`{"packages": {}}`))
+		assert.Error(t, err)
+	})
+
+	t.Run("implement Stringer correctly", func(t *testing.T) {
+		assert.Equal(t, "php collector", PHP{}.String())
+	})
+}
@@ -0,0 +1,70 @@
+package collectors
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+// pipfileLock represents Pipfile.lock files generated by pipenv.
+type pipfileLock struct {
+	Default map[string]pipfileLockedPackage `json:"default"`
+	Develop map[string]pipfileLockedPackage `json:"develop"`
+}
+
+type pipfileLockedPackage struct {
+	Version string   `json:"version"`
+	Hashes  []string `json:"hashes"`
+	Git     string   `json:"git"`
+	Ref     string   `json:"ref"`
+	File    string   `json:"file"`
+	Path    string   `json:"path"`
+}
+
+/*
+parsePipfileLock converts Pipfile.lock contents into pkg:pypi components. Packages from the default section
+are required, packages from the develop section are optional. Local path packages are skipped, git packages
+use the locked commit as their version.
+*/
+func parsePipfileLock(contents []byte) (*cdx.BOM, error) {
+	var lockfile pipfileLock
+	if err := json.Unmarshal(contents, &lockfile); err != nil {
+		return nil, fmt.Errorf("can't parse Pipfile.lock: %w", err)
+	}
+
+	var components []cdx.Component
+	collect := func(packages map[string]pipfileLockedPackage, scope cdx.Scope) {
+		names := make([]string, 0, len(packages))
+		for name := range packages {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			p := packages[name]
+			if p.Path != "" {
+				continue
+			}
+
+			version := strings.TrimPrefix(p.Version, "==")
+			if p.Git != "" && version == "" {
+				version = p.Ref
+			}
+			component := newLibraryComponent(pypiPURL(name, version), name, version)
+			component.Scope = scope
+			addHashes(&component, hashesFromPyPIDigests(p.Hashes)...)
+			if p.Git != "" {
+				addExternalReference(&component, cdx.ERTypeVCS, strings.TrimPrefix(p.Git, "git+")+"#"+p.Ref)
+			}
+			addExternalReference(&component, cdx.ERTypeDistribution, p.File)
+			components = append(components, component)
+		}
+	}
+	collect(lockfile.Default, cdx.ScopeRequired)
+	collect(lockfile.Develop, cdx.ScopeOptional)
+
+	return bomFromComponents(uniqueComponents(components)), nil
+}
@@ -0,0 +1,288 @@
+package collectors
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"gopkg.in/yaml.v3"
+)
+
+/*
+pnpmLockfile represents pnpm-lock.yaml files. Lockfiles of single package projects (5.x & 6.x) don't have
+importers - their dependencies are stored at the top level instead.
+*/
+type pnpmLockfile struct {
+	LockfileVersion string                  `yaml:"lockfileVersion"`
+	Importers       map[string]pnpmImporter `yaml:"importers"`
+	pnpmImporter    `yaml:",inline"`
+	Packages        map[string]pnpmPackage  `yaml:"packages"`
+	Snapshots       map[string]pnpmSnapshot `yaml:"snapshots"` // lockfileVersion 9.x
+}
+
+// pnpmImporter represents a single workspace package inside pnpm-lock.yaml.
+type pnpmImporter struct {
+	Dependencies         map[string]pnpmVersion `yaml:"dependencies"`
+	DevDependencies      map[string]pnpmVersion `yaml:"devDependencies"`
+	OptionalDependencies map[string]pnpmVersion `yaml:"optionalDependencies"`
+}
+
+type pnpmPackage struct {
+	Name       string `yaml:"name"`
+	Version    string `yaml:"version"`
+	Dev        *bool  `yaml:"dev"`
+	Resolution struct {
+		Integrity string `yaml:"integrity"`
+		Tarball   string `yaml:"tarball"`
+		Repo      string `yaml:"repo"`
+		Commit    string `yaml:"commit"`
+	} `yaml:"resolution"`
+	pnpmSnapshot `yaml:",inline"` // lockfileVersion 5.x & 6.x
+}
+
+type pnpmSnapshot struct {
+	Dependencies         map[string]string `yaml:"dependencies"`
+	OptionalDependencies map[string]string `yaml:"optionalDependencies"`
+}
+
+/*
+pnpmVersion is a resolved importer dependency version. Lockfile version 5.x stores it as a plain string,
+while newer lockfiles store it as a mapping of specifier & version.
+*/
+type pnpmVersion string
+
+func (v *pnpmVersion) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*v = pnpmVersion(value.Value)
+		return nil
+	}
+
+	var dependency struct {
+		Version string `yaml:"version"`
+	}
+	if err := value.Decode(&dependency); err != nil {
+		return err
+	}
+	*v = pnpmVersion(dependency.Version)
+
+	return nil
+}
+
+// pnpmNode is a single resolved package inside the pnpm dependency graph.
+type pnpmNode struct {
+	name, version string
+	pkg           pnpmPackage
+	dependencies  pnpmSnapshot
+}
+
+/*
+majorVersion returns the lockfile major version. E.g. 5 for lockfileVersion: 5.4, 9 for lockfileVersion: '9.0'
+*/
+func (l pnpmLockfile) majorVersion() int {
+	major, _, _ := strings.Cut(l.LockfileVersion, ".")
+	v, err := strconv.Atoi(major)
+	if err != nil {
+		return 0
+	}
+
+	return v
+}
+
+/*
+nodeID normalizes package keys of every lockfile version to name@version form. Peer dependency suffixes are kept,
+because the same package version can be resolved multiple times with different peers. E.g. given the following input:
+
+	/@babel/core/7.16.0_react@18.2.0 (5.x)
+	/@babel/core@7.16.0(react@18.2.0) (6.x)
+	@babel/core@7.16.0(react@18.2.0) (9.x)
+
+this function will return: "@babel/core", "7.16.0_react@18.2.0" or "7.16.0(react@18.2.0)"
+*/
+func (l pnpmLockfile) nodeID(key string) (string, string) {
+	key = strings.TrimPrefix(key, "/")
+	if l.majorVersion() < 6 {
+		// Package names can contain underscores, so the name is made of the first path segment (two if scoped)
+		segments := strings.SplitN(key, "/", 3)
+		if strings.HasPrefix(key, "@") && len(segments) == 3 {
+			return segments[0] + "/" + segments[1], segments[2]
+		}
+		if name, version, ok := strings.Cut(key, "/"); ok {
+			return name, version
+		}
+		return key, ""
+	}
+	if i := strings.Index(key[min(1, len(key)):], "@"); i != -1 {
+		return key[:i+1], key[i+2:]
+	}
+
+	return key, ""
+}
+
+// resolve converts a dependency reference to a node ID. Empty string is returned for workspace links.
+func (l pnpmLockfile) resolve(dependencyName, reference string) string {
+	if strings.HasPrefix(reference, "link:") || strings.HasPrefix(reference, "file:") {
+		return ""
+	}
+
+	// Aliased dependencies reference other packages. E.g. /string-width/4.2.3 or string-width@4.2.3
+	head, _, _ := strings.Cut(reference, "(")
+	if strings.HasPrefix(reference, "/") || (l.majorVersion() >= 9 && strings.Contains(head[min(1, len(head)):], "@")) {
+		name, version := l.nodeID(reference)
+		return name + "@" + version
+	}
+
+	return dependencyName + "@" + reference
+}
+
+// nodes builds the pnpm dependency graph, keyed by node IDs.
+func (l pnpmLockfile) nodes() map[string]pnpmNode {
+	nodes := make(map[string]pnpmNode)
+
+	if l.majorVersion() >= 9 {
+		for key, snapshot := range l.Snapshots {
+			name, version := l.nodeID(key)
+			plainVersion, _, _ := strings.Cut(version, "(")
+			nodes[name+"@"+version] = pnpmNode{
+				name:         name,
+				version:      plainVersion,
+				pkg:          l.Packages[name+"@"+plainVersion],
+				dependencies: snapshot,
+			}
+		}
+
+		return nodes
+	}
+
+	for key, pkg := range l.Packages {
+		name, version := l.nodeID(key)
+		plainVersion, _, _ := strings.Cut(version, "(")
+		plainVersion, _, _ = strings.Cut(plainVersion, "_")
+		nodes[name+"@"+version] = pnpmNode{
+			name:         name,
+			version:      plainVersion,
+			pkg:          pkg,
+			dependencies: pkg.pnpmSnapshot,
+		}
+	}
+
+	return nodes
+}
+
+/*
+parsePNPMLockfile converts pnpm-lock.yaml contents (lockfile versions 5.x, 6.x & 9.x) into pkg:npm components.
+The dependency graph is walked from every importer (workspace package): packages reachable through dependencies
+are required, while packages reachable only through devDependencies or optionalDependencies are optional.
+Every component records the importers that depend on it as properties.
+*/
+func parsePNPMLockfile(contents []byte) ([]cdx.Component, error) {
+	var lockfile pnpmLockfile
+	if err := yaml.Unmarshal(contents, &lockfile); err != nil {
+		return nil, fmt.Errorf("can't parse pnpm-lock.yaml: %w", err)
+	}
+
+	importers := lockfile.Importers
+	if len(importers) == 0 {
+		importers = map[string]pnpmImporter{".": lockfile.pnpmImporter}
+	}
+
+	nodes := lockfile.nodes()
+	required := make(map[string]bool)
+	importersOf := make(map[string]map[string]bool)
+	visited := make(map[string]bool)
+
+	var visit func(id, importer string, isRequired bool)
+	visit = func(id, importer string, isRequired bool) {
+		node, exists := nodes[id]
+		state := fmt.Sprintf("%s|%s|%t", id, importer, isRequired)
+		if !exists || visited[state] {
+			return
+		}
+		visited[state] = true
+
+		if importersOf[id] == nil {
+			importersOf[id] = make(map[string]bool)
+		}
+		importersOf[id][importer] = true
+		required[id] = required[id] || isRequired
+
+		for name, reference := range node.dependencies.Dependencies {
+			visit(lockfile.resolve(name, reference), importer, isRequired)
+		}
+		for name, reference := range node.dependencies.OptionalDependencies {
+			visit(lockfile.resolve(name, reference), importer, false)
+		}
+	}
+
+	for path, importer := range importers {
+		for name, version := range importer.Dependencies {
+			visit(lockfile.resolve(name, string(version)), path, true)
+		}
+		for name, version := range importer.OptionalDependencies {
+			visit(lockfile.resolve(name, string(version)), path, false)
+		}
+		for name, version := range importer.DevDependencies {
+			visit(lockfile.resolve(name, string(version)), path, false)
+		}
+	}
+
+	ids := make([]string, 0, len(nodes))
+	for id := range nodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	components := make([]cdx.Component, 0, len(nodes))
+	for _, id := range ids {
+		node := nodes[id]
+		name, version := node.name, node.version
+		if node.pkg.Name != "" { // Tarball & git dependencies
+			name, version = node.pkg.Name, node.pkg.Version
+		}
+
+		component := newLibraryComponent(npmPURL(name, version), name, version)
+		isRequired := required[id]
+		if _, reached := importersOf[id]; !reached {
+			isRequired = node.pkg.Dev == nil || !*node.pkg.Dev
+		}
+		component.Scope = cdx.ScopeOptional
+		if isRequired {
+			component.Scope = cdx.ScopeRequired
+		}
+
+		resolution := node.pkg.Resolution
+		addHashes(&component, hashesFromSRI(resolution.Integrity)...)
+		addExternalReference(&component, cdx.ERTypeDistribution, resolution.Tarball)
+		if resolution.Repo != "" {
+			addExternalReference(&component, cdx.ERTypeVCS, resolution.Repo+"#"+resolution.Commit)
+		}
+
+		dependents := make([]string, 0, len(importersOf[id]))
+		for importer := range importersOf[id] {
+			dependents = append(dependents, importer)
+		}
+		sort.Strings(dependents)
+		for _, importer := range dependents {
+			addProperty(&component, "pnpm:importer", importer)
+		}
+		components = append(components, component)
+	}
+
+	return uniqueComponents(components), nil
+}
+
+// pnpmImporterPaths returns relative paths of every workspace package listed in pnpm-lock.yaml.
+func pnpmImporterPaths(contents []byte) ([]string, error) {
+	var lockfile pnpmLockfile
+	if err := yaml.Unmarshal(contents, &lockfile); err != nil {
+		return nil, fmt.Errorf("can't parse pnpm-lock.yaml: %w", err)
+	}
+
+	paths := make([]string, 0, len(lockfile.Importers))
+	for path := range lockfile.Importers {
+		paths = append(paths, path)
+	}
+
+	return paths, nil
+}
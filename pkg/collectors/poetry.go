@@ -0,0 +1,112 @@
+package collectors
+
+import (
+	"fmt"
+
+	"github.com/BurntSushi/toml"
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/vinted/sbomsftw/pkg/bomtools"
+)
+
+/*
+poetryLockfile represents poetry.lock files. Lockfile versions 1.x store package hashes in the metadata
+table, newer versions store them next to every package.
+*/
+type poetryLockfile struct {
+	Packages []poetryPackage `toml:"package"`
+	Metadata struct {
+		Files map[string][]poetryFile `toml:"files"` // Lockfile version 1.x
+	} `toml:"metadata"`
+}
+
+type poetryPackage struct {
+	Name     string       `toml:"name"`
+	Version  string       `toml:"version"`
+	Category string       `toml:"category"` // Poetry < 1.5
+	Groups   []string     `toml:"groups"`   // Poetry >= 2.0
+	Files    []poetryFile `toml:"files"`
+	Source   struct {
+		Type              string `toml:"type"`
+		URL               string `toml:"url"`
+		Reference         string `toml:"reference"`
+		ResolvedReference string `toml:"resolved_reference"`
+	} `toml:"source"`
+}
+
+type poetryFile struct {
+	File string `toml:"file"`
+	Hash string `toml:"hash"`
+}
+
+/*
+isOptional reports whether the package is needed only for development. Older poetry versions
+store this as the dev category, newer ones list dependency groups the package belongs to.
+*/
+func (p poetryPackage) isOptional() bool {
+	if p.Category == "dev" {
+		return true
+	}
+	if len(p.Groups) == 0 {
+		return false
+	}
+	for _, g := range p.Groups {
+		if g == "main" {
+			return false
+		}
+	}
+
+	return true
+}
+
+/*
+parsePoetryLockfile converts poetry.lock contents into pkg:pypi components. Packages from the dev category
+& non-main dependency groups are optional. Local directory & file packages are skipped.
+*/
+func parsePoetryLockfile(contents []byte) (*cdx.BOM, error) {
+	var lockfile poetryLockfile
+	if _, err := toml.Decode(string(contents), &lockfile); err != nil {
+		return nil, fmt.Errorf("can't parse poetry.lock: %w", err)
+	}
+
+	metadataFiles := make(map[string][]poetryFile, len(lockfile.Metadata.Files))
+	for name, files := range lockfile.Metadata.Files {
+		metadataFiles[bomtools.NormalizePyPIName(name)] = files
+	}
+
+	components := make([]cdx.Component, 0, len(lockfile.Packages))
+	for _, p := range lockfile.Packages {
+		if p.Source.Type == "directory" || p.Source.Type == "file" {
+			continue
+		}
+
+		component := newLibraryComponent(pypiPURL(p.Name, p.Version), p.Name, p.Version)
+		component.Scope = cdx.ScopeRequired
+		if p.isOptional() {
+			component.Scope = cdx.ScopeOptional
+		}
+
+		files := p.Files
+		if len(files) == 0 {
+			files = metadataFiles[bomtools.NormalizePyPIName(p.Name)]
+		}
+		digests := make([]string, 0, len(files))
+		for _, f := range files {
+			digests = append(digests, f.Hash)
+		}
+		addHashes(&component, hashesFromPyPIDigests(digests)...)
+
+		switch p.Source.Type {
+		case "git":
+			reference := p.Source.ResolvedReference
+			if reference == "" {
+				reference = p.Source.Reference
+			}
+			addExternalReference(&component, cdx.ERTypeVCS, p.Source.URL+"#"+reference)
+		case "url", "legacy":
+			addExternalReference(&component, cdx.ERTypeDistribution, p.Source.URL)
+		}
+		components = append(components, component)
+	}
+
+	return bomFromComponents(uniqueComponents(components)), nil
+}
@@ -0,0 +1,121 @@
+package collectors
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"gopkg.in/yaml.v3"
+)
+
+// pubspecLock represents pubspec.lock files of Dart & Flutter projects.
+type pubspecLock struct {
+	Packages map[string]pubPackage `yaml:"packages"`
+	SDKs     map[string]string     `yaml:"sdks"`
+}
+
+type pubPackage struct {
+	Dependency  string         `yaml:"dependency"`
+	Description pubDescription `yaml:"description"`
+	Source      string         `yaml:"source"`
+	Version     string         `yaml:"version"`
+}
+
+// pubDescription describes where a package comes from. SDK packages store the SDK name as a plain string.
+type pubDescription struct {
+	Name        string `yaml:"name"`
+	SHA256      string `yaml:"sha256"`
+	URL         string `yaml:"url"`
+	Path        string `yaml:"path"`
+	ResolvedRef string `yaml:"resolved-ref"`
+}
+
+func (d *pubDescription) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		d.Name = value.Value
+		return nil
+	}
+	type plain pubDescription
+
+	return value.Decode((*plain)(d))
+}
+
+/*
+pubScope maps pubspec.lock dependency kinds onto CycloneDX scopes. Dev dependencies are optional. Transitive
+dependencies are required, because the lockfile doesn't tell whether they are pulled in by dev dependencies.
+*/
+func pubScope(dependency string) cdx.Scope {
+	if dependency == "direct dev" {
+		return cdx.ScopeOptional
+	}
+
+	return cdx.ScopeRequired
+}
+
+/*
+parsePubspecLock converts pubspec.lock contents into pkg:pub components. Package sources are recorded as
+external references: hosted packages reference their package repository, git packages the resolved commit
+& path packages their location. Packages provided by SDKs (flutter, flutter_test) are skipped, SDK
+constraints are recorded as BOM properties instead.
+*/
+func parsePubspecLock(contents []byte) (*cdx.BOM, error) {
+	var lockfile pubspecLock
+	if err := yaml.Unmarshal(contents, &lockfile); err != nil {
+		return nil, fmt.Errorf("can't parse pubspec.lock: %w", err)
+	}
+
+	names := make([]string, 0, len(lockfile.Packages))
+	for name := range lockfile.Packages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	components := make([]cdx.Component, 0, len(names))
+	for _, name := range names {
+		p := lockfile.Packages[name]
+		if p.Source == "sdk" {
+			continue
+		}
+
+		component := newLibraryComponent(packageURL("pub", "", name, p.Version), name, p.Version)
+		component.Scope = pubScope(p.Dependency)
+		addProperty(&component, "pub:dependency", p.Dependency)
+
+		switch d := p.Description; p.Source {
+		case "hosted":
+			if d.SHA256 != "" {
+				addHashes(&component, cdx.Hash{Algorithm: cdx.HashAlgoSHA256, Value: d.SHA256})
+			}
+			if d.URL != "" {
+				addExternalReference(&component, cdx.ERTypeDistribution, strings.TrimSuffix(d.URL, "/")+"/packages/"+name)
+			}
+		case "git":
+			vcsURL := d.URL
+			if d.ResolvedRef != "" {
+				vcsURL += "#" + d.ResolvedRef
+			}
+			addExternalReference(&component, cdx.ERTypeVCS, vcsURL)
+		case "path":
+			addExternalReference(&component, cdx.ERTypeOther, d.Path)
+		}
+		components = append(components, component)
+	}
+
+	bom := bomFromComponents(components)
+	if len(lockfile.SDKs) > 0 {
+		sdks := make([]string, 0, len(lockfile.SDKs))
+		for sdk := range lockfile.SDKs {
+			sdks = append(sdks, sdk)
+		}
+		sort.Strings(sdks)
+
+		properties := make([]cdx.Property, 0, len(sdks))
+		for _, sdk := range sdks {
+			properties = append(properties, cdx.Property{Name: propertyPrefix + "pub:sdk", Value: sdk + " " + lockfile.SDKs[sdk]})
+		}
+		bom.Properties = &properties
+	}
+
+	return bom, nil
+}
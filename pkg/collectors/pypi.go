@@ -0,0 +1,52 @@
+package collectors
+
+import (
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/vinted/sbomsftw/pkg/bomtools"
+)
+
+// pypiPURL formats a pkg:pypi Package URL with the package name normalized.
+func pypiPURL(name, version string) string {
+	return packageURL("pypi", "", bomtools.NormalizePyPIName(name), version)
+}
+
+/*
+hashFromPyPIDigest converts hashes in algorithm:digest form, used by both poetry & pipenv lockfiles,
+to CycloneDX hashes. E.g. sha256:e8b3b1c1b4... Unsupported algorithms are skipped - false is returned for those.
+*/
+func hashFromPyPIDigest(digest string) (cdx.Hash, bool) {
+	algorithms := map[string]cdx.HashAlgorithm{
+		"md5":    cdx.HashAlgoMD5,
+		"sha1":   cdx.HashAlgoSHA1,
+		"sha256": cdx.HashAlgoSHA256,
+		"sha384": cdx.HashAlgoSHA384,
+		"sha512": cdx.HashAlgoSHA512,
+	}
+
+	algorithm, value, ok := strings.Cut(digest, ":")
+	hashAlgorithm, supported := algorithms[algorithm]
+	if !ok || !supported || value == "" {
+		return cdx.Hash{}, false
+	}
+
+	return cdx.Hash{Algorithm: hashAlgorithm, Value: value}, true
+}
+
+// hashesFromPyPIDigests converts a list of algorithm:digest hashes to CycloneDX hashes, skipping duplicates.
+func hashesFromPyPIDigests(digests []string) []cdx.Hash {
+	seen := make(map[string]bool)
+	hashes := make([]cdx.Hash, 0, len(digests))
+	for _, d := range digests {
+		if seen[d] {
+			continue
+		}
+		seen[d] = true
+		if h, ok := hashFromPyPIDigest(d); ok {
+			hashes = append(hashes, h)
+		}
+	}
+
+	return hashes
+}
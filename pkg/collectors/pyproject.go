@@ -0,0 +1,162 @@
+package collectors
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+// Name, extras & the rest of PEP 508 requirement specifiers. E.g. requests[socks]>=2.31; python_version >= "3.8"
+var pep508Pattern = regexp.MustCompile(`^\s*([A-Za-z0-9][A-Za-z0-9._-]*)\s*(\[[^]]*])?\s*([^;]*)`)
+
+var errNoPEP621Metadata = errors.New("pyproject.toml has no [project] table")
+
+/*
+pyproject represents pyproject.toml files with PEP 621 project metadata. Dependency groups are declared in the
+PEP 735 dependency-groups table, or in tool specific tables by older uv & pdm releases.
+*/
+type pyproject struct {
+	Project *struct {
+		Name                 string              `toml:"name"`
+		Version              string              `toml:"version"`
+		Dependencies         []string            `toml:"dependencies"`
+		OptionalDependencies map[string][]string `toml:"optional-dependencies"`
+	} `toml:"project"`
+	DependencyGroups map[string][]any `toml:"dependency-groups"`
+	Tool             struct {
+		UV struct {
+			DevDependencies []string `toml:"dev-dependencies"`
+		} `toml:"uv"`
+		PDM struct {
+			DevDependencies map[string][]string `toml:"dev-dependencies"`
+		} `toml:"pdm"`
+	} `toml:"tool"`
+}
+
+/*
+pep508Component converts a PEP 508 requirement specifier into a pkg:pypi component. Only versions pinned
+with == or === can be resolved, so other specifiers produce components without versions. Direct references
+to git repositories & archives are recorded as external references. E.g.
+
+	requests>=2.31
+	rich==13.7.0; python_version >= "3.8"
+	mylib @ git+https://github.com/acme/mylib.git@v1.0.0
+*/
+func pep508Component(requirement string) (cdx.Component, bool) {
+	matches := pep508Pattern.FindStringSubmatch(requirement)
+	if matches == nil {
+		return cdx.Component{}, false
+	}
+	name, specifier := matches[1], strings.TrimSpace(matches[3])
+
+	var version, reference string
+	switch {
+	case strings.HasPrefix(specifier, "@"):
+		reference = strings.TrimSpace(specifier[1:])
+	case strings.HasPrefix(specifier, "==="):
+		version = strings.TrimSpace(specifier[3:])
+	case strings.HasPrefix(specifier, "==") && !strings.ContainsAny(specifier, ",*"):
+		version = strings.TrimSpace(specifier[2:])
+	}
+
+	component := newLibraryComponent(pypiPURL(name, version), name, version)
+	if vcsURL, ok := strings.CutPrefix(reference, "git+"); ok {
+		// Revisions follow the last @ of the path. E.g. https://github.com/acme/mylib.git@v1.0.0
+		if i := strings.LastIndex(vcsURL, "@"); i > strings.Index(vcsURL, "://")+2 && !strings.Contains(vcsURL[i:], "/") {
+			vcsURL = vcsURL[:i] + "#" + vcsURL[i+1:]
+		}
+		addExternalReference(&component, cdx.ERTypeVCS, vcsURL)
+	} else if reference != "" {
+		addExternalReference(&component, cdx.ERTypeDistribution, reference)
+	}
+
+	return component, true
+}
+
+/*
+dependencyGroupRequirements returns requirement specifiers of a PEP 735 dependency group. Groups can include
+other groups with {include-group = "name"} tables, those are resolved recursively.
+*/
+func dependencyGroupRequirements(groups map[string][]any, group string, visited map[string]bool) []string {
+	if visited[group] {
+		return nil
+	}
+	visited[group] = true
+
+	var requirements []string
+	for _, r := range groups[group] {
+		switch r := r.(type) {
+		case string:
+			requirements = append(requirements, r)
+		case map[string]any:
+			if included, ok := r["include-group"].(string); ok {
+				requirements = append(requirements, dependencyGroupRequirements(groups, included, visited)...)
+			}
+		}
+	}
+
+	return requirements
+}
+
+/*
+parsePyproject converts dependencies declared in pyproject.toml files into pkg:pypi components. Project
+dependencies are required, while optional dependencies (extras) & dependency groups are optional.
+Names of extras & groups are recorded as properties.
+*/
+func parsePyproject(contents []byte) (*cdx.BOM, error) {
+	var project pyproject
+	if _, err := toml.Decode(string(contents), &project); err != nil {
+		return nil, fmt.Errorf("can't parse pyproject.toml: %w", err)
+	}
+	if project.Project == nil {
+		return nil, errNoPEP621Metadata
+	}
+
+	var components []cdx.Component
+	collect := func(requirements []string, scope cdx.Scope, property, value string) {
+		for _, r := range requirements {
+			component, ok := pep508Component(r)
+			if !ok {
+				continue
+			}
+			component.Scope = scope
+			addProperty(&component, property, value)
+			components = append(components, component)
+		}
+	}
+	collect(project.Project.Dependencies, cdx.ScopeRequired, "", "")
+
+	for _, extra := range sortedKeys(project.Project.OptionalDependencies) {
+		collect(project.Project.OptionalDependencies[extra], cdx.ScopeOptional, "python:extra", extra)
+	}
+
+	groups := make(map[string][]string)
+	for group := range project.DependencyGroups {
+		groups[group] = dependencyGroupRequirements(project.DependencyGroups, group, make(map[string]bool))
+	}
+	if len(project.Tool.UV.DevDependencies) > 0 {
+		groups["dev"] = append(groups["dev"], project.Tool.UV.DevDependencies...)
+	}
+	for group, requirements := range project.Tool.PDM.DevDependencies {
+		groups[group] = append(groups[group], requirements...)
+	}
+	for _, group := range sortedKeys(groups) {
+		collect(groups[group], cdx.ScopeOptional, "python:group", group)
+	}
+
+	bom := bomFromComponents(uniqueComponents(components))
+	if project.Project.Name != "" {
+		bom.Metadata = &cdx.Metadata{Component: &cdx.Component{
+			Type:       cdx.ComponentTypeApplication,
+			Name:       project.Project.Name,
+			Version:    project.Project.Version,
+			PackageURL: pypiPURL(project.Project.Name, project.Project.Version),
+		}}
+	}
+
+	return bom, nil
+}
@@ -2,23 +2,29 @@ package collectors
 
 import (
 	"context"
-	"errors"
+	"fmt"
 	"os"
 	fp "path/filepath"
 	"regexp"
-	"sort"
-	"strings"
 
 	log "github.com/sirupsen/logrus"
 
 	cdx "github.com/CycloneDX/cyclonedx-go"
 )
 
-var (
-	condaEnvPattern             = regexp.MustCompile(`environment.*\.ya?ml`)
-	condaDependencyPattern      = regexp.MustCompile(`- .*=\d.*`)
-	condaLooseDependencyPattern = regexp.MustCompile(`^[\w-]*=\d.*$`)
-)
+var condaEnvPattern = regexp.MustCompile(`environment.*\.ya?ml`)
+
+// Lockfiles & pyproject.toml files parsed natively, without cdxgen.
+var pythonLockfileParsers = map[string]func([]byte) (*cdx.BOM, error){
+	"poetry.lock":    parsePoetryLockfile,
+	"Pipfile.lock":   parsePipfileLock,
+	"uv.lock":        parseUvLockfile,
+	"pdm.lock":       parsePdmLockfile,
+	"pyproject.toml": parsePyproject,
+}
+
+// Lockfiles resolving dependencies declared in pyproject.toml files.
+var pyprojectLockfiles = []string{"poetry.lock", "uv.lock", "pdm.lock"}
 
 type Python struct {
 	executor shellExecutor
@@ -37,7 +43,7 @@ func (p Python) MatchLanguageFiles(isDir bool, filepath string) bool {
 	}
 	filename := fp.Base(filepath)
 
-	for _, f := range []string{"setup.py", "requirements.txt", "Pipfile.lock", "poetry.lock"} {
+	for _, f := range []string{"setup.py", "requirements.txt", "Pipfile.lock", "poetry.lock", "pyproject.toml", "uv.lock", "pdm.lock"} {
 		if filename == f {
 			return true
 		}
@@ -50,8 +56,23 @@ func (p Python) MatchLanguageFiles(isDir bool, filepath string) bool {
 	return condaEnvPattern.MatchString(filename)
 }
 
-// GenerateBOM implements LanguageCollector interface
+/*
+GenerateBOM implements LanguageCollector interface. Conda environment files, pyproject.toml files & poetry.lock,
+Pipfile.lock, uv.lock & pdm.lock files are parsed natively, other package files are handed to cdxgen. Falls back
+to cdxgen when a lockfile can't be parsed.
+*/
 func (p Python) GenerateBOM(ctx context.Context, bomRoot string) (*cdx.BOM, error) {
+	const language = "python"
+
+	if condaEnvPattern.MatchString(fp.Base(bomRoot)) {
+		// cdxgen doesn't support conda, so the environment file can be left in place
+		contents, err := os.ReadFile(bomRoot)
+		if err != nil {
+			return nil, fmt.Errorf("can't read conda environment file: %w", err)
+		}
+		return parseCondaEnvironment(contents)
+	}
+
 	defer func() {
 		if err := os.RemoveAll(bomRoot); err != nil {
 			log.WithFields(log.Fields{
@@ -60,93 +81,59 @@ func (p Python) GenerateBOM(ctx context.Context, bomRoot string) (*cdx.BOM, erro
 			}).Debugf("GenerateBOM: can't remove %s", bomRoot)
 		}
 	}()
-	const language = "python"
+
+	if parse, ok := pythonLockfileParsers[fp.Base(bomRoot)]; ok {
+		if contents, err := os.ReadFile(bomRoot); err == nil {
+			bom, err := parse(contents)
+			if err == nil {
+				return bom, nil
+			}
+			log.WithFields(log.Fields{
+				"collector":       p,
+				"collection path": bomRoot,
+				"error":           err,
+			}).Debugf("can't parse %s natively, falling back to cdxgen", fp.Base(bomRoot))
+		}
+	}
 
 	return p.executor.bomFromCdxgen(ctx, fp.Dir(bomRoot), language, false)
 }
 
 /*
-BootstrapLanguageFiles implements LanguageCollector interface. Traverses bom roots and converts
-all conda environment.yml files to a single requirements.txt file. This is needed because cdxgen
-doesn't support conda package manager.
+BootstrapLanguageFiles implements LanguageCollector interface. pyproject.toml files are skipped when
+a lockfile next to them or in one of their parent directories pins the declared dependencies. uv, PDM
+& Poetry workspaces keep a single lockfile at their root, which covers pyproject.toml files of members.
 */
 func (p Python) BootstrapLanguageFiles(_ context.Context, bomRoots []string) []string {
-	// Extract dependencies from conda environment.yml files
-	dependenciesFromCondaEnv := func(condaEnv string) (requirements []string) {
-		for _, dependency := range condaDependencyPattern.FindAllString(condaEnv, -1) {
-			dependency = strings.TrimPrefix(dependency, "- ")
-			if condaLooseDependencyPattern.MatchString(dependency) && len(strings.Split(dependency, "=")) == 2 {
-				// CDXGen wants all dependencies in requirements.txt to be with double == signs.
-				dependency = strings.Join(strings.Split(dependency, "="), "==")
+	locked := make(map[string]bool)
+	for _, r := range bomRoots {
+		for _, l := range pyprojectLockfiles {
+			if fp.Base(r) == l {
+				locked[fp.Dir(r)] = true
 			}
-			requirements = append(requirements, dependency)
 		}
-
-		return requirements
 	}
 
-	// Filter out duplicates
-	uniqueRequirements := func(requirements []string) (uniqueRequirements []string) {
-		temp := make(map[string]bool)
-		for _, r := range requirements {
-			if _, ok := temp[r]; !ok {
-				temp[r] = true
-				uniqueRequirements = append(uniqueRequirements, r)
+	filtered := make([]string, 0, len(bomRoots))
+	lockedByAncestor := func(dir string) bool {
+		for ; ; dir = fp.Dir(dir) {
+			if locked[dir] {
+				return true
+			}
+			if dir == fp.Dir(dir) {
+				return false
 			}
-		}
-		sort.Strings(uniqueRequirements)
-
-		return
-	}
-
-	writeRequirementsFile := func(dir string, requirements []string) {
-		formatted := strings.Join(requirements, "\n")
-		if err := os.WriteFile(fp.Join(dir, "requirements.txt"), []byte(formatted), 0o644); err != nil {
-			log.WithFields(log.Fields{
-				"collector": p,
-				"error":     err,
-			}).Debug("can't write to requirements file")
 		}
 	}
 
-	for dir, files := range SplitPaths(bomRoots) {
-		var requirements []string
-		for _, f := range files {
-			if condaEnvPattern.MatchString(f) {
-				condaEnv, err := os.ReadFile(fp.Join(dir, f))
-				if err != nil {
-					log.WithFields(log.Fields{
-						"collector": p,
-						"error":     err,
-					}).Debugf("can't open conda environment file at: %s", fp.Join(dir, f))
-					continue
-				}
-				requirements = append(requirements, dependenciesFromCondaEnv(string(condaEnv))...)
-			}
-		}
-		if len(requirements) == 0 {
-			continue
-		}
-		requirements = uniqueRequirements(requirements) // Filter duplicates & convert to set
-		requirementsFilePath := fp.Join(dir, "requirements.txt")
-		currentContents, err := os.ReadFile(requirementsFilePath)
-		if err != nil {
-			if errors.Is(err, os.ErrNotExist) {
-				log.WithField("collector", p).Debugf("%s: doesn't exist, creating a new one", requirementsFilePath)
-			} else {
-				log.WithFields(log.Fields{
-					"collector": p,
-					"error":     err,
-				}).Debugf("can't read %s: Creating requirements.txt nonetheless", requirementsFilePath)
-			}
-			writeRequirementsFile(dir, requirements)
+	for _, r := range bomRoots {
+		if fp.Base(r) == "pyproject.toml" && lockedByAncestor(fp.Dir(r)) {
 			continue
 		}
-		// requirements.txt exist. Merge conda env and requirements.txt contents
-		requirements = uniqueRequirements(append(requirements, strings.Fields(string(currentContents))...))
-		writeRequirementsFile(dir, requirements)
+		filtered = append(filtered, r)
 	}
-	return bomRoots
+
+	return filtered
 }
 
 // String implements LanguageCollector interface
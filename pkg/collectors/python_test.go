@@ -11,6 +11,7 @@ import (
 	cdx "github.com/CycloneDX/cyclonedx-go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/vinted/sbomsftw/pkg/bomtools"
 )
 
 func TestPythonCollector(t *testing.T) {
@@ -34,6 +35,36 @@ func TestPythonCollector(t *testing.T) {
 		assert.ElementsMatch(t, bomRoots, got)
 	})
 
+	t.Run("skip pyproject.toml files next to lockfiles when bootstrapping", func(t *testing.T) {
+		got := Python{}.BootstrapLanguageFiles(context.Background(), []string{
+			"/tmp/some-random-dir/pyproject.toml",
+			"/tmp/some-random-dir/uv.lock",
+			"/tmp/other-dir/pyproject.toml",
+			"/tmp/other-dir/requirements.txt",
+		})
+		assert.ElementsMatch(t, []string{
+			"/tmp/some-random-dir/uv.lock",
+			"/tmp/other-dir/pyproject.toml",
+			"/tmp/other-dir/requirements.txt",
+		}, got)
+	})
+
+	t.Run("skip pyproject.toml files of workspace members when bootstrapping", func(t *testing.T) {
+		got := Python{}.BootstrapLanguageFiles(context.Background(), []string{
+			"/tmp/workspace/pyproject.toml",
+			"/tmp/workspace/uv.lock",
+			"/tmp/workspace/packages/api/pyproject.toml",
+			"/tmp/workspace/packages/worker/pyproject.toml",
+			"/tmp/workspace/packages/worker/requirements.txt",
+			"/tmp/workspace-unlocked/pyproject.toml",
+		})
+		assert.ElementsMatch(t, []string{
+			"/tmp/workspace/uv.lock",
+			"/tmp/workspace/packages/worker/requirements.txt",
+			"/tmp/workspace-unlocked/pyproject.toml",
+		}, got)
+	})
+
 	t.Run("generate BOM correctly", func(t *testing.T) {
 		const bomRoot = "/tmp/some-random-dir"
 		executor := new(mockShellExecutor)
@@ -70,103 +101,336 @@ func TestPythonCollector(t *testing.T) {
 		for _, f := range []string{"setup.py", "requirements.txt", "Pipfile.lock", "poetry.lock"} {
 			assert.False(t, pythonCollector.MatchLanguageFiles(true, f))
 		}
+		for _, f := range []string{"pyproject.toml", "/opt/uv.lock", "/opt/pdm.lock"} {
+			assert.True(t, pythonCollector.MatchLanguageFiles(false, f))
+		}
 		assert.False(t, pythonCollector.MatchLanguageFiles(false, "environment-dev.yaml"))
 		assert.False(t, pythonCollector.MatchLanguageFiles(false, "/etc/passwd"))
 	})
 
-	t.Run("don't create requirements.txt if no conda environment files exist", func(t *testing.T) {
+	t.Run("don't mutate the repository when bootstrapping conda environments", func(t *testing.T) {
+		tempDir := createTempDir(t)
+		defer func(path string) {
+			if err := os.RemoveAll(path); err != nil {
+				log.Errorf("failed to remove temporary directory %s: %v", path, err)
+			}
+		}(tempDir)
+
+		contents, err := os.ReadFile("../../integration/test/conda-envs/test-conda-env3.7.yml")
+		require.NoError(t, err)
+		condaEnv := filepath.Join(tempDir, "environment_3.7.yml")
+		require.NoError(t, os.WriteFile(condaEnv, contents, 0o644))
+
+		got := Python{}.BootstrapLanguageFiles(context.Background(), []string{condaEnv})
+		assert.Equal(t, []string{condaEnv}, got)
+
+		entries, err := os.ReadDir(tempDir)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, "environment_3.7.yml", entries[0].Name())
+	})
+
+	t.Run("generate BOM natively from conda environments", func(t *testing.T) {
+		tempDir := createTempDir(t)
+		defer os.RemoveAll(tempDir)
+
+		contents, err := os.ReadFile("../../integration/test/conda-envs/test-conda-env.yml")
+		require.NoError(t, err)
+		condaEnv := filepath.Join(tempDir, "environment.yml")
+		require.NoError(t, os.WriteFile(condaEnv, contents, 0o644))
+
+		executor := new(mockShellExecutor)
+		got, err := Python{executor: executor}.GenerateBOM(context.Background(), condaEnv)
+		require.NoError(t, err)
+		executor.AssertNotCalled(t, "bomFromCdxgen")
+
+		assert.ElementsMatch(t, []string{
+			"pkg:conda/gcsfs@0.6.0",
+			"pkg:conda/google-cloud-storage@1.24.1",
+			"pkg:conda/gunicorn@20.1.0",
+			"pkg:conda/pip@20.0.2",
+			"pkg:conda/pydantic@1.6.1",
+			"pkg:conda/python@3.7.6",
+			"pkg:pypi/json-logging@1.4.0rc1",
+			"pkg:pypi/prometheus-client@0.9.0",
+		}, purls(t, got))
+		assert.Equal(t, &[]cdx.Property{
+			{Name: "sbomsftw:conda:channel", Value: "defaults"},
+			{Name: "sbomsftw:conda:channel", Value: "conda-forge"},
+		}, got.Properties)
+
+		_, err = os.Stat(condaEnv)
+		assert.NoError(t, err, "conda environment files must be left in place")
+	})
+
+	t.Run("parse conda channels, builds & pip requirements correctly", func(t *testing.T) {
+		contents, err := os.ReadFile("../../integration/test/conda-envs/test-conda-env-channels.yml")
+		require.NoError(t, err)
+
+		got, err := parseCondaEnvironment(contents)
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []string{
+			"pkg:conda/libgcc-ng",
+			"pkg:conda/numpy@1.24.3?build=py310ha4c1d20_0&channel=conda-forge",
+			"pkg:conda/pandas@2.0.2?build=py310h7cbd5c2_0",
+			"pkg:conda/pip",
+			"pkg:conda/python",
+			"pkg:conda/scipy",
+			"pkg:pypi/requests@2.31.0",
+			"pkg:pypi/typing-extensions@4.7.1",
+			"pkg:pypi/urllib3",
+		}, purls(t, got))
+	})
+
+	t.Run("keep conda qualifiers & channels when merging", func(t *testing.T) {
+		contents, err := os.ReadFile("../../integration/test/conda-envs/test-conda-env-channels.yml")
+		require.NoError(t, err)
+
+		bom, err := parseCondaEnvironment(contents)
+		require.NoError(t, err)
+		got, err := bomtools.MergeSBOMs(bomtools.MergeSBOMParam{SBOMs: []*cdx.BOM{bom}})
+		require.NoError(t, err)
+
+		assert.Contains(t, purls(t, got), "pkg:conda/numpy@1.24.3?build=py310ha4c1d20_0&channel=conda-forge")
+		assert.Contains(t, purls(t, got), "pkg:conda/pandas@2.0.2?build=py310h7cbd5c2_0")
+		assert.Equal(t, &[]cdx.Property{
+			{Name: "sbomsftw:conda:channel", Value: "conda-forge"},
+			{Name: "sbomsftw:conda:channel", Value: "defaults"},
+		}, got.Properties)
+	})
+
+	t.Run("return an error for malformed conda environments", func(t *testing.T) {
+		_, err := parseCondaEnvironment([]byte("dependencies: {"))
+		assert.Error(t, err)
+	})
+
+	t.Run("generate BOM natively from poetry.lock", func(t *testing.T) {
 		tempDir := createTempDir(t)
-		err := os.WriteFile(filepath.Join(tempDir, "setup.py"), nil, 0o644)
+		defer os.RemoveAll(tempDir)
+
+		contents, err := os.ReadFile("../../integration/test/python/poetry-v2/poetry.lock")
 		require.NoError(t, err)
+		lockfile := filepath.Join(tempDir, "poetry.lock")
+		require.NoError(t, os.WriteFile(lockfile, contents, 0o644))
 
-		Python{}.BootstrapLanguageFiles(context.Background(), []string{filepath.Join(tempDir, "setup.py")})
-		_, err = os.ReadFile(filepath.Join(tempDir, "requirements.txt"))
-		// assert.NotNil(t, err)
+		executor := new(mockShellExecutor)
+		got, err := Python{executor: executor}.GenerateBOM(context.Background(), lockfile)
+		require.NoError(t, err)
+		executor.AssertNotCalled(t, "bomFromCdxgen")
+
+		components := componentsByPURL(t, got)
+		assert.ElementsMatch(t, []string{
+			"pkg:pypi/black@24.3.0",
+			"pkg:pypi/requests@2.31.0",
+			"pkg:pypi/typing-extensions@4.7.1",
+		}, purls(t, got))
+		assert.Equal(t, cdx.ScopeOptional, components["pkg:pypi/black@24.3.0"].Scope)
+		assert.Equal(t, cdx.ScopeRequired, components["pkg:pypi/requests@2.31.0"].Scope)
+		assert.Equal(t, &[]cdx.Hash{
+			{Algorithm: cdx.HashAlgoSHA256, Value: "58cd2187c01e70e6e26505bca751777aa9f2ee0b7f4300988b709f44e013003f"},
+			{Algorithm: cdx.HashAlgoSHA256, Value: "942c5a758f98d790eaed1a29cb6eefc7ffb0d1cf7af05c3d2791656dbd6ad1e1"},
+		}, components["pkg:pypi/requests@2.31.0"].Hashes)
+		assert.Equal(t, &[]cdx.ExternalReference{{
+			Type: cdx.ERTypeVCS,
+			URL:  "https://github.com/python/typing_extensions.git#8b2f8b4a2d5e1c9f0a3b6c7d8e9f0a1b2c3d4e5f",
+		}}, components["pkg:pypi/typing-extensions@4.7.1"].ExternalReferences)
+
+		_, err = os.Stat(lockfile)
 		assert.ErrorIs(t, err, os.ErrNotExist)
 	})
 
-	t.Run("convert conda environments to requirements.txt correctly", func(t *testing.T) {
-		/*
-			Setup test environment. Setup function creates the following temp directory structure:
-			/tmp/random-dir/environment_3.7.yml
-			/tmp/random-dir/environment_3.8.yml
-			/tmp/random-dir/inner-dir/environment.yml
-			/tmp/random-dir/inner-dir/requirements.txt
-		*/
-		setup := func() (string, string) {
-			tempDir := createTempDir(t)
+	t.Run("parse poetry.lock v1 correctly", func(t *testing.T) {
+		contents, err := os.ReadFile("../../integration/test/python/poetry-v1/poetry.lock")
+		require.NoError(t, err)
 
-			contents, err := os.ReadFile("../../integration/test/conda-envs/test-conda-env3.7.yml")
-			require.NoError(t, err)
+		got, err := parsePoetryLockfile(contents)
+		require.NoError(t, err)
 
-			err = os.WriteFile(filepath.Join(tempDir, "environment_3.7.yml"), contents, 0o644)
-			require.NoError(t, err)
+		components := componentsByPURL(t, got)
+		assert.ElementsMatch(t, []string{
+			"pkg:pypi/flask@2.2.5",
+			"pkg:pypi/pytest@7.4.0",
+			"pkg:pypi/werkzeug@2.3.6",
+		}, purls(t, got))
+		assert.Equal(t, cdx.ScopeOptional, components["pkg:pypi/pytest@7.4.0"].Scope)
+		assert.Equal(t, cdx.ScopeRequired, components["pkg:pypi/werkzeug@2.3.6"].Scope)
+		assert.Equal(t, "Werkzeug", components["pkg:pypi/werkzeug@2.3.6"].Name)
+		assert.Equal(t, &[]cdx.Hash{
+			{Algorithm: cdx.HashAlgoSHA256, Value: "58107ed83443e86067e41eff4631b058178191a355886f8e479e347fa1285fdf"},
+			{Algorithm: cdx.HashAlgoSHA256, Value: "edee9b0a7ff26621bd5a8c10ff484ae28737a2410d99b0bb9a6850c7fb977aa0"},
+		}, components["pkg:pypi/flask@2.2.5"].Hashes)
+	})
 
-			contents, err = os.ReadFile("../../integration/test/conda-envs/test-conda-env3.8.yml")
-			require.NoError(t, err)
+	t.Run("parse Pipfile.lock correctly", func(t *testing.T) {
+		contents, err := os.ReadFile("../../integration/test/python/pipenv/Pipfile.lock")
+		require.NoError(t, err)
 
-			err = os.WriteFile(filepath.Join(tempDir, "environment_3.8.yml"), contents, 0o644)
-			require.NoError(t, err)
+		got, err := parsePipfileLock(contents)
+		require.NoError(t, err)
 
-			innerDir, err := os.MkdirTemp(tempDir, "innerDir")
-			require.NoError(t, err)
+		components := componentsByPURL(t, got)
+		assert.ElementsMatch(t, []string{
+			"pkg:pypi/django@4.2.1",
+			"pkg:pypi/pytest@7.4.0",
+			"pkg:pypi/requests@2a6f290bc09324406708a4d404a88a45d848ddf9",
+			"pkg:pypi/zope-interface@6.0",
+		}, purls(t, got))
+		assert.Equal(t, cdx.ScopeRequired, components["pkg:pypi/django@4.2.1"].Scope)
+		assert.Equal(t, cdx.ScopeOptional, components["pkg:pypi/pytest@7.4.0"].Scope)
+		assert.Len(t, *components["pkg:pypi/django@4.2.1"].Hashes, 2)
+		assert.Equal(t, &[]cdx.ExternalReference{{
+			Type: cdx.ERTypeVCS,
+			URL:  "https://github.com/psf/requests.git#2a6f290bc09324406708a4d404a88a45d848ddf9",
+		}}, components["pkg:pypi/requests@2a6f290bc09324406708a4d404a88a45d848ddf9"].ExternalReferences)
+	})
 
-			contents, err = os.ReadFile("../../integration/test/conda-envs/test-conda-env.yml")
-			require.NoError(t, err)
+	t.Run("generate BOM natively from uv.lock", func(t *testing.T) {
+		tempDir := createTempDir(t)
+		defer os.RemoveAll(tempDir)
 
-			err = os.WriteFile(filepath.Join(innerDir, "environment.yml"), contents, 0o644)
-			require.NoError(t, err)
+		contents, err := os.ReadFile("../../integration/test/python/uv/uv.lock")
+		require.NoError(t, err)
+		lockfile := filepath.Join(tempDir, "uv.lock")
+		require.NoError(t, os.WriteFile(lockfile, contents, 0o644))
 
-			contents, err = os.ReadFile("../../integration/test/conda-envs/test-reqs.txt")
-			require.NoError(t, err)
+		executor := new(mockShellExecutor)
+		got, err := Python{executor: executor}.GenerateBOM(context.Background(), lockfile)
+		require.NoError(t, err)
+		executor.AssertNotCalled(t, "bomFromCdxgen")
 
-			err = os.WriteFile(filepath.Join(innerDir, "requirements.txt"), contents, 0o644)
-			require.NoError(t, err)
+		components := componentsByPURL(t, got)
+		assert.ElementsMatch(t, []string{
+			"pkg:pypi/certifi@2024.2.2",
+			"pkg:pypi/psycopg@3.1.18",
+			"pkg:pypi/pysocks@1.7.1",
+			"pkg:pypi/pytest@8.1.1",
+			"pkg:pypi/requests@2.31.0",
+			"pkg:pypi/rich@13.7.0",
+			"pkg:pypi/ruff@0.4.4",
+			"pkg:pypi/vinted-utils@1.2.0",
+		}, purls(t, got))
 
-			return tempDir, innerDir
+		// certifi is required by both requests & pytest, pysocks is required through the socks extra of requests
+		for _, purl := range []string{"pkg:pypi/certifi@2024.2.2", "pkg:pypi/pysocks@1.7.1", "pkg:pypi/requests@2.31.0"} {
+			assert.Equal(t, cdx.ScopeRequired, components[purl].Scope, purl)
 		}
+		for _, purl := range []string{"pkg:pypi/psycopg@3.1.18", "pkg:pypi/pytest@8.1.1", "pkg:pypi/ruff@0.4.4"} {
+			assert.Equal(t, cdx.ScopeOptional, components[purl].Scope, purl)
+		}
+		assert.Equal(t, &[]cdx.Hash{
+			{Algorithm: cdx.HashAlgoSHA256, Value: "0569859f95fc761b18b45ef421b1290a0f65f147e92a1e5eb3e635f9a5e4e66f"},
+			{Algorithm: cdx.HashAlgoSHA256, Value: "dc383c07b76109f368f6106eee2b593b04a011ea4d55f652c6ca24a754d1cdd1"},
+		}, components["pkg:pypi/certifi@2024.2.2"].Hashes)
+		assert.Equal(t, &[]cdx.ExternalReference{{
+			Type: cdx.ERTypeVCS,
+			URL:  "https://github.com/vinted/vinted-utils.git#5e7a1b2c3d4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b",
+		}}, components["pkg:pypi/vinted-utils@1.2.0"].ExternalReferences)
 
-		tempDir, innerDir := setup()
-		defer func(path string) {
-			err := os.RemoveAll(path)
-			if err != nil {
-				log.Errorf("failed to remove temporary directory %s: %v", path, err)
-			}
-		}(tempDir)
+		_, err = os.Stat(lockfile)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
 
-		bomRoots := []string{
-			filepath.Join(tempDir, "environment_3.7.yml"),
-			filepath.Join(tempDir, "environment_3.8.yml"),
-			filepath.Join(innerDir, "environment.yml"),
-		}
+	t.Run("parse uv.lock with cyclic extras", func(t *testing.T) {
+		got, err := parseUvLockfile([]byte(`
+version = 1
+
+[[package]]
+name = "app"
+version = "0.1.0"
+source = { virtual = "." }
+dependencies = [{ name = "alpha", extra = ["x"] }]
+
+[[package]]
+name = "alpha"
+version = "1.0.0"
+source = { registry = "https://pypi.org/simple" }
+
+[package.optional-dependencies]
+x = [{ name = "beta", extra = ["y"] }]
+
+[[package]]
+name = "beta"
+version = "2.0.0"
+source = { registry = "https://pypi.org/simple" }
+
+[package.optional-dependencies]
+y = [{ name = "alpha", extra = ["x"] }]
+`))
+		require.NoError(t, err)
+
+		components := componentsByPURL(t, got)
+		assert.Equal(t, cdx.ScopeRequired, components["pkg:pypi/alpha@1.0.0"].Scope)
+		assert.Equal(t, cdx.ScopeRequired, components["pkg:pypi/beta@2.0.0"].Scope)
+	})
+
+	t.Run("parse pdm.lock correctly", func(t *testing.T) {
+		contents, err := os.ReadFile("../../integration/test/python/pdm/pdm.lock")
+		require.NoError(t, err)
+
+		got, err := parsePdmLockfile(contents)
+		require.NoError(t, err)
+
+		components := componentsByPURL(t, got)
+		assert.ElementsMatch(t, []string{
+			"pkg:pypi/asgiref@3.8.1",
+			"pkg:pypi/django@5.0.4",
+			"pkg:pypi/pytest-django@4.8.0",
+			"pkg:pypi/vinted-auth@0.9.0",
+		}, purls(t, got))
+		assert.Equal(t, cdx.ScopeRequired, components["pkg:pypi/asgiref@3.8.1"].Scope)
+		assert.Equal(t, cdx.ScopeOptional, components["pkg:pypi/pytest-django@4.8.0"].Scope)
+		assert.Len(t, *components["pkg:pypi/django@5.0.4"].Hashes, 2)
+		assert.Equal(t, &[]cdx.ExternalReference{{
+			Type: cdx.ERTypeVCS,
+			URL:  "https://github.com/vinted/vinted-auth.git#0c1d2e3f4a5b6c7d8e9f0a1b2c3d4e5f6a7b8c9d",
+		}}, components["pkg:pypi/vinted-auth@0.9.0"].ExternalReferences)
+	})
+
+	t.Run("parse pyproject.toml correctly", func(t *testing.T) {
+		contents, err := os.ReadFile("../../integration/test/python/uv/pyproject.toml")
+		require.NoError(t, err)
+
+		got, err := parsePyproject(contents)
+		require.NoError(t, err)
+
+		components := componentsByPURL(t, got)
+		assert.ElementsMatch(t, []string{
+			"pkg:pypi/psycopg",
+			"pkg:pypi/pytest",
+			"pkg:pypi/requests",
+			"pkg:pypi/rich@13.7.0",
+			"pkg:pypi/ruff@0.4.4",
+			"pkg:pypi/vinted-utils",
+		}, purls(t, got))
+		assert.Equal(t, cdx.ScopeRequired, components["pkg:pypi/rich@13.7.0"].Scope)
+		assert.Equal(t, cdx.ScopeOptional, components["pkg:pypi/psycopg"].Scope)
+		assert.Equal(t, &[]cdx.Property{
+			{Name: "sbomsftw:python:extra", Value: "postgres"},
+		}, components["pkg:pypi/psycopg"].Properties)
+		// ruff is included into the dev group from the lint group
+		assert.Equal(t, &[]cdx.Property{
+			{Name: "sbomsftw:python:group", Value: "dev"},
+		}, components["pkg:pypi/ruff@0.4.4"].Properties)
+		assert.Equal(t, &[]cdx.ExternalReference{{
+			Type: cdx.ERTypeVCS,
+			URL:  "https://github.com/vinted/vinted-utils.git#v1.2.0",
+		}}, components["pkg:pypi/vinted-utils"].ExternalReferences)
+
+		require.NotNil(t, got.Metadata)
+		assert.Equal(t, "pkg:pypi/sample-service@0.1.0", got.Metadata.Component.PackageURL)
+	})
 
-		Python{}.BootstrapLanguageFiles(context.Background(), bomRoots)
-
-		got, err := os.ReadFile(filepath.Join(tempDir, "requirements.txt"))
-		require.NoError(t, err)
-		want := `Fastapi==0.65.1
-gunicorn==20.1.0
-json-logging==1.4.0rc1
-kafka-python==2.0.2
-pip==20.0.2
-prometheus-client==0.9.0
-python-dotenv==0.17.1
-python==3.7.6
-python==3.8.12
-statsd==3.3.0
-uvicorn==0.13.4`
-		assert.Equal(t, want, string(got))
-
-		got, err = os.ReadFile(filepath.Join(innerDir, "requirements.txt"))
-		require.NoError(t, err)
-		want = `gcsfs==0.6.0
-google-cloud-storage==1.24.1
-gunicorn==20.1.0
-json-logging==1.4.0rc1
-pip==20.0.2
-prometheus-client==0.9.0
-pydantic==1.6.1
-python==3.7.6`
-		assert.Equal(t, want, string(got))
+	t.Run("return an error for malformed lockfiles", func(t *testing.T) {
+		_, err := parsePoetryLockfile([]byte("[[package]\nname ="))
+		assert.Error(t, err)
+		_, err = parsePipfileLock([]byte("{"))
+		assert.Error(t, err)
+		_, err = parseUvLockfile([]byte("[[package]\nname ="))
+		assert.Error(t, err)
+		_, err = parsePdmLockfile([]byte("[[package]\nname ="))
+		assert.Error(t, err)
+		_, err = parsePyproject([]byte("[tool.poetry]\nname = \"app\""))
+		assert.ErrorIs(t, err, errNoPEP621Metadata)
 	})
 }
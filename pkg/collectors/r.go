@@ -0,0 +1,54 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"os"
+	fp "path/filepath"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+const renvLockfileName = "renv.lock"
+
+// R collects dependencies of R projects locked with renv.
+type R struct{}
+
+func NewRCollector() R {
+	return R{}
+}
+
+// MatchLanguageFiles implements LanguageCollector interface.
+func (r R) MatchLanguageFiles(isDir bool, filepath string) bool {
+	if isDir {
+		return false
+	}
+	for _, d := range strings.Split(fp.Dir(filepath), string(os.PathSeparator)) {
+		if d == "renv" || d == "packrat" { // Ignore project libraries
+			return false
+		}
+	}
+
+	return fp.Base(filepath) == renvLockfileName
+}
+
+// BootstrapLanguageFiles implements LanguageCollector interface.
+func (r R) BootstrapLanguageFiles(_ context.Context, bomRoots []string) []string {
+	return SquashToDirs(bomRoots)
+}
+
+// GenerateBOM implements LanguageCollector interface. renv.lock is parsed natively, without requiring R.
+func (r R) GenerateBOM(_ context.Context, bomRoot string) (*cdx.BOM, error) {
+	contents, err := os.ReadFile(fp.Join(bomRoot, renvLockfileName))
+	if err != nil {
+		return nil, fmt.Errorf("can't read %s: %w", renvLockfileName, err)
+	}
+
+	return parseRenvLock(contents)
+}
+
+// String implements LanguageCollector interface.
+func (r R) String() string {
+	return "r collector"
+}
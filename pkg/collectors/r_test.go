@@ -0,0 +1,74 @@
+package collectors
+
+import (
+	"context"
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRCollector(t *testing.T) {
+	t.Run("match correct package files", func(t *testing.T) {
+		rCollector := R{}
+		assert.True(t, rCollector.MatchLanguageFiles(false, "renv.lock"))
+		assert.True(t, rCollector.MatchLanguageFiles(false, "/opt/analysis/renv.lock"))
+		assert.False(t, rCollector.MatchLanguageFiles(false, "/opt/analysis/renv/staging/renv.lock"))
+		assert.False(t, rCollector.MatchLanguageFiles(false, "/opt/analysis/DESCRIPTION"))
+		assert.False(t, rCollector.MatchLanguageFiles(true, "renv.lock"))
+	})
+
+	t.Run("bootstrap language files correctly", func(t *testing.T) {
+		got := R{}.BootstrapLanguageFiles(context.Background(), []string{
+			"/tmp/some-random-dir/renv.lock",
+			"/tmp/some-random-dir/reports/renv.lock",
+		})
+		assert.ElementsMatch(t, []string{"/tmp/some-random-dir", "/tmp/some-random-dir/reports"}, got)
+	})
+
+	t.Run("implement Stringer correctly", func(t *testing.T) {
+		assert.Equal(t, "r collector", R{}.String())
+	})
+
+	t.Run("generate BOM natively from renv.lock", func(t *testing.T) {
+		got, err := R{}.GenerateBOM(context.Background(), "../../integration/test/r")
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []string{
+			"pkg:bioconductor/BiocGenerics@0.46.0",
+			"pkg:cran/R6@2.5.1",
+			"pkg:cran/dplyr@1.1.3",
+			"pkg:cran/rlang@1.1.1",
+			"pkg:cran/vintedutils@0.2.0",
+		}, purls(t, got))
+
+		components := componentsByPURL(t, got)
+		dplyr := components["pkg:cran/dplyr@1.1.3"]
+		assert.Equal(t, cdx.ScopeRequired, dplyr.Scope)
+		assert.Equal(t, &[]cdx.Property{
+			{Name: "sbomsftw:renv:source", Value: "Repository"},
+			{Name: "sbomsftw:renv:repository", Value: "CRAN"},
+			{Name: "sbomsftw:renv:hash", Value: "eb5742d256a0d9306d85ea68756d8187"},
+		}, dplyr.Properties)
+		assert.Equal(t, &[]cdx.ExternalReference{
+			{Type: cdx.ERTypeDistribution, URL: "https://cloud.r-project.org"},
+		}, dplyr.ExternalReferences)
+		assert.Equal(t, &[]cdx.ExternalReference{
+			{Type: cdx.ERTypeVCS, URL: "https://github.com/vinted/vintedutils#5a1b2c3d4e5f60718293a4b5c6d7e8f901234567"},
+		}, components["pkg:cran/vintedutils@0.2.0"].ExternalReferences)
+
+		require.NotNil(t, got.Dependencies)
+		dependencies := make(map[string][]string)
+		for _, d := range *got.Dependencies {
+			dependencies[d.Ref] = *d.Dependencies
+		}
+		assert.Equal(t, []string{"pkg:cran/R6@2.5.1", "pkg:cran/rlang@1.1.1"}, dependencies["pkg:cran/dplyr@1.1.3"])
+		assert.Equal(t, &[]cdx.Property{{Name: "sbomsftw:renv:r", Value: "4.3.1"}}, got.Properties)
+	})
+
+	t.Run("return an error for malformed renv.lock", func(t *testing.T) {
+		_, err := parseRenvLock([]byte(`{"Packages": [`))
+		assert.Error(t, err)
+	})
+}
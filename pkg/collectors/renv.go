@@ -0,0 +1,131 @@
+package collectors
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+// renvLockfile represents renv.lock files of R projects.
+type renvLockfile struct {
+	R struct {
+		Version      string `json:"Version"`
+		Repositories []struct {
+			Name string `json:"Name"`
+			URL  string `json:"URL"`
+		} `json:"Repositories"`
+	} `json:"R"`
+	Packages map[string]renvPackage `json:"Packages"`
+}
+
+// Web hosts of remotes packages are installed from. renv.lock files record API hosts instead.
+var renvRemoteHosts = map[string]string{"github": "github.com", "gitlab": "gitlab.com", "bitbucket": "bitbucket.org"}
+
+type renvPackage struct {
+	Package        string   `json:"Package"`
+	Version        string   `json:"Version"`
+	Source         string   `json:"Source"`
+	Repository     string   `json:"Repository"`
+	Hash           string   `json:"Hash"`
+	Requirements   []string `json:"Requirements"`
+	RemoteType     string   `json:"RemoteType"`
+	RemoteHost     string   `json:"RemoteHost"`
+	RemoteUsername string   `json:"RemoteUsername"`
+	RemoteRepo     string   `json:"RemoteRepo"`
+	RemoteURL      string   `json:"RemoteUrl"`
+	RemoteSha      string   `json:"RemoteSha"`
+}
+
+func (p renvPackage) purl() string {
+	if p.Source == "Bioconductor" {
+		return packageURL("bioconductor", "", p.Package, p.Version)
+	}
+
+	return packageURL("cran", "", p.Package, p.Version)
+}
+
+/*
+vcsURL returns the repository URL of packages installed from GitHub, GitLab, Bitbucket or git remotes, pinned to
+the installed commit. API hosts of remotes are swapped for their web hosts. An empty string is returned for packages
+installed from package repositories.
+*/
+func (p renvPackage) vcsURL() string {
+	var repository string
+	switch remoteType := strings.ToLower(p.RemoteType); remoteType {
+	case "github", "gitlab", "bitbucket":
+		host := strings.TrimSuffix(p.RemoteHost, "/api/v3") // GitHub Enterprise API
+		if host == "" || strings.HasPrefix(host, "api.") {
+			host = renvRemoteHosts[remoteType]
+		}
+		repository = fmt.Sprintf("https://%s/%s/%s", host, p.RemoteUsername, p.RemoteRepo)
+	case "git", "git2r", "xgit":
+		repository = p.RemoteURL
+	default:
+		return ""
+	}
+	if p.RemoteSha != "" {
+		repository += "#" + p.RemoteSha
+	}
+
+	return repository
+}
+
+/*
+parseRenvLock converts renv.lock contents into pkg:cran components & preserves the dependency graph of
+lockfiles written by renv 1.0 & newer. Bioconductor packages become pkg:bioconductor components.
+renv hashes are computed from package metadata instead of archives, so they're recorded as properties.
+*/
+func parseRenvLock(contents []byte) (*cdx.BOM, error) {
+	var lockfile renvLockfile
+	if err := json.Unmarshal(contents, &lockfile); err != nil {
+		return nil, fmt.Errorf("can't parse renv.lock: %w", err)
+	}
+
+	repositories := make(map[string]string, len(lockfile.R.Repositories))
+	for _, r := range lockfile.R.Repositories {
+		repositories[r.Name] = r.URL
+	}
+
+	names := make([]string, 0, len(lockfile.Packages))
+	for name, p := range lockfile.Packages {
+		if p.Package == "" {
+			p.Package = name
+			lockfile.Packages[name] = p
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	components := make([]cdx.Component, 0, len(names))
+	dependencies := make([]cdx.Dependency, 0, len(names))
+	for _, name := range names {
+		p := lockfile.Packages[name]
+		component := newLibraryComponent(p.purl(), p.Package, p.Version)
+		component.Scope = cdx.ScopeRequired
+		addProperty(&component, "renv:source", p.Source)
+		addProperty(&component, "renv:repository", p.Repository)
+		addProperty(&component, "renv:hash", p.Hash)
+		addExternalReference(&component, cdx.ERTypeDistribution, repositories[p.Repository])
+		addExternalReference(&component, cdx.ERTypeVCS, p.vcsURL())
+		components = append(components, component)
+
+		dependsOn := make([]string, 0, len(p.Requirements))
+		for _, r := range p.Requirements {
+			if requirement, ok := lockfile.Packages[r]; ok {
+				dependsOn = append(dependsOn, requirement.purl())
+			}
+		}
+		dependencies = append(dependencies, cdx.Dependency{Ref: p.purl(), Dependencies: &dependsOn})
+	}
+
+	bom := bomFromComponents(uniqueComponents(components))
+	bom.Dependencies = &dependencies
+	if lockfile.R.Version != "" {
+		bom.Properties = &[]cdx.Property{{Name: propertyPrefix + "renv:r", Value: lockfile.R.Version}}
+	}
+
+	return bom, nil
+}
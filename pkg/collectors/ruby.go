@@ -2,6 +2,7 @@ package collectors
 
 import (
 	"context"
+	"os"
 	fp "path/filepath"
 
 	cdx "github.com/CycloneDX/cyclonedx-go"
@@ -73,8 +74,24 @@ func (r Ruby) BootstrapLanguageFiles(ctx context.Context, bomRoots []string) []s
 	return bootstrappedRoots
 }
 
-// GenerateBOM implements LanguageCollector interface.
+/*
+GenerateBOM implements LanguageCollector interface. Gemfile.lock is parsed natively, so a working
+Ruby toolchain is only needed when the lockfile is missing or can't be parsed.
+*/
 func (r Ruby) GenerateBOM(ctx context.Context, bomRoot string) (*cdx.BOM, error) {
 	const language = "ruby"
+
+	if contents, err := os.ReadFile(fp.Join(bomRoot, gemfileLock)); err == nil {
+		bom, err := parseGemfileLock(contents)
+		if err == nil {
+			return bom, nil
+		}
+		log.WithFields(log.Fields{
+			"collector":       r,
+			"collection path": bomRoot,
+			"error":           err,
+		}).Debug("can't parse Gemfile.lock natively, falling back to cdxgen")
+	}
+
 	return r.executor.bomFromCdxgen(ctx, bomRoot, language, false)
 }
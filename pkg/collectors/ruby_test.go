@@ -6,6 +6,8 @@ import (
 
 	cdx "github.com/CycloneDX/cyclonedx-go"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vinted/sbomsftw/pkg/bomtools"
 )
 
 func TestRubyCollector(t *testing.T) {
@@ -50,4 +52,65 @@ func TestRubyCollector(t *testing.T) {
 	t.Run("implement Stringer correctly", func(t *testing.T) {
 		assert.Equal(t, "ruby collector", Ruby{}.String())
 	})
+	t.Run("generate BOM natively from Gemfile.lock", func(t *testing.T) {
+		executor := new(mockShellExecutor)
+		got, err := Ruby{executor: executor}.GenerateBOM(context.Background(), "../../integration/test/ruby")
+		require.NoError(t, err)
+		executor.AssertNotCalled(t, "bomFromCdxgen")
+
+		assert.ElementsMatch(t, []string{
+			"pkg:gem/bundler@2.4.10",
+			"pkg:gem/mini_portile2@2.8.1",
+			"pkg:gem/nokogiri@1.13.10",
+			"pkg:gem/nokogiri@1.13.10?platform=arm64-darwin",
+			"pkg:gem/nokogiri@1.13.10?platform=x86_64-linux",
+			"pkg:gem/racc@1.6.2",
+			"pkg:gem/rack-attack@6.7.0",
+			"pkg:gem/rack@2.2.6.4",
+		}, purls(t, got))
+
+		components := componentsByPURL(t, got)
+		nokogiri := components["pkg:gem/nokogiri@1.13.10?platform=x86_64-linux"]
+		assert.Equal(t, &[]cdx.Property{{Name: "sbomsftw:bundler:indirect", Value: "false"}}, nokogiri.Properties)
+		assert.Equal(t, &[]cdx.Hash{{
+			Algorithm: cdx.HashAlgoSHA256,
+			Value:     "0c2a2b7d5f8a1c3e4b6d7f9a0b1c2d3e6f4a3e1a9c0e2b7d5f8a1c3e4b6d7f9a",
+		}}, nokogiri.Hashes)
+
+		assert.Equal(t, &[]cdx.ExternalReference{{
+			Type: cdx.ERTypeVCS,
+			URL:  "https://github.com/vinted/rack-attack.git#6f4a3e1a9c0e2b7d5f8a1c3e4b6d7f9a0b1c2d3e",
+		}}, components["pkg:gem/rack-attack@6.7.0"].ExternalReferences)
+		assert.Equal(t, &[]cdx.Property{
+			{Name: "sbomsftw:bundler:indirect", Value: "true"},
+		}, components["pkg:gem/racc@1.6.2"].Properties)
+
+		assert.Equal(t, &[]cdx.Property{
+			{Name: "sbomsftw:bundler:platform", Value: "arm64-darwin"},
+			{Name: "sbomsftw:bundler:platform", Value: "ruby"},
+			{Name: "sbomsftw:bundler:platform", Value: "x86_64-linux"},
+			{Name: "sbomsftw:bundler:ruby", Value: "ruby 3.1.2p20"},
+		}, got.Properties)
+	})
+
+	t.Run("keep gem platforms & BOM properties when merging", func(t *testing.T) {
+		executor := new(mockShellExecutor)
+		bom, err := Ruby{executor: executor}.GenerateBOM(context.Background(), "../../integration/test/ruby")
+		require.NoError(t, err)
+
+		got, err := bomtools.MergeSBOMs(bomtools.MergeSBOMParam{SBOMs: []*cdx.BOM{bom}})
+		require.NoError(t, err)
+
+		assert.Subset(t, purls(t, got), []string{
+			"pkg:gem/nokogiri@1.13.10",
+			"pkg:gem/nokogiri@1.13.10?platform=arm64-darwin",
+			"pkg:gem/nokogiri@1.13.10?platform=x86_64-linux",
+		})
+		assert.Equal(t, &[]cdx.Property{
+			{Name: "sbomsftw:bundler:platform", Value: "arm64-darwin"},
+			{Name: "sbomsftw:bundler:platform", Value: "ruby"},
+			{Name: "sbomsftw:bundler:platform", Value: "x86_64-linux"},
+			{Name: "sbomsftw:bundler:ruby", Value: "ruby 3.1.2p20"},
+		}, got.Properties)
+	})
 }
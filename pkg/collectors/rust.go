@@ -2,9 +2,11 @@ package collectors
 
 import (
 	"context"
+	"os"
 	fp "path/filepath"
 
 	cdx "github.com/CycloneDX/cyclonedx-go"
+	log "github.com/sirupsen/logrus"
 )
 
 type Rust struct {
@@ -38,9 +40,25 @@ func (g Rust) BootstrapLanguageFiles(_ context.Context, bomRoots []string) []str
 	return SquashToDirs(bomRoots)
 }
 
-// GenerateBOM implements LanguageCollector interface.
+/*
+GenerateBOM implements LanguageCollector interface. Cargo.lock is parsed natively, preserving the
+dependency graph. Falls back to cdxgen when the lockfile is missing or can't be parsed.
+*/
 func (g Rust) GenerateBOM(ctx context.Context, bomRoot string) (*cdx.BOM, error) {
 	const language = "rust"
+
+	if contents, err := os.ReadFile(fp.Join(bomRoot, "Cargo.lock")); err == nil {
+		bom, err := parseCargoLockfile(contents)
+		if err == nil {
+			return bom, nil
+		}
+		log.WithFields(log.Fields{
+			"collector":       g,
+			"collection path": bomRoot,
+			"error":           err,
+		}).Debug("can't parse Cargo.lock natively, falling back to cdxgen")
+	}
+
 	return g.executor.bomFromCdxgen(ctx, bomRoot, language, false)
 }
 
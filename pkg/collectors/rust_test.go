@@ -2,10 +2,12 @@ package collectors
 
 import (
 	"context"
+	"os"
 	"testing"
 
 	cdx "github.com/CycloneDX/cyclonedx-go"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestRustCollector(t *testing.T) {
@@ -47,4 +49,67 @@ func TestRustCollector(t *testing.T) {
 	t.Run("implement Stringer correctly", func(t *testing.T) {
 		assert.Equal(t, "rust collector", Rust{}.String())
 	})
+	t.Run("generate BOM natively from Cargo.lock v4", func(t *testing.T) {
+		executor := new(mockShellExecutor)
+		got, err := Rust{executor: executor}.GenerateBOM(context.Background(), "../../integration/test/rust/v4")
+		require.NoError(t, err)
+		executor.AssertNotCalled(t, "bomFromCdxgen")
+
+		components := componentsByPURL(t, got)
+		assert.ElementsMatch(t, []string{
+			"pkg:cargo/cfg-if@1.0.0",
+			"pkg:cargo/log@0.3.9",
+			"pkg:cargo/log@0.4.20",
+			"pkg:cargo/sample-service@0.1.0",
+			"pkg:cargo/tokio@1.35.0",
+			"pkg:cargo/utils@0.2.0",
+		}, purls(t, got))
+
+		assert.Equal(t, &[]cdx.Hash{{
+			Algorithm: cdx.HashAlgoSHA256,
+			Value:     "baf1de4339761588bc0619e3cbc0120ee582ebb74b53b4efbf79117bd2da40fd",
+		}}, components["pkg:cargo/cfg-if@1.0.0"].Hashes)
+
+		tokio := components["pkg:cargo/tokio@1.35.0"]
+		assert.Equal(t, &[]cdx.Property{{Name: "sbomsftw:cargo:source", Value: "git"}}, tokio.Properties)
+		assert.Equal(t, &[]cdx.ExternalReference{{
+			Type: cdx.ERTypeVCS,
+			URL:  "https://github.com/tokio-rs/tokio#2ce9e6ed8a7e4d1d4ef5c8d1c3e4b5a6f7e8d9c0",
+		}}, tokio.ExternalReferences)
+		assert.Equal(t, &[]cdx.Property{
+			{Name: "sbomsftw:cargo:source", Value: "path"},
+		}, components["pkg:cargo/utils@0.2.0"].Properties)
+
+		require.NotNil(t, got.Dependencies)
+		dependencies := make(map[string][]string)
+		for _, d := range *got.Dependencies {
+			dependencies[d.Ref] = *d.Dependencies
+		}
+		assert.Equal(t, []string{
+			"pkg:cargo/cfg-if@1.0.0",
+			"pkg:cargo/log@0.3.9",
+			"pkg:cargo/tokio@1.35.0",
+			"pkg:cargo/utils@0.2.0",
+		}, dependencies["pkg:cargo/sample-service@0.1.0"])
+		assert.Equal(t, []string{"pkg:cargo/log@0.4.20"}, dependencies["pkg:cargo/log@0.3.9"])
+		assert.Equal(t, []string{"pkg:cargo/cfg-if@1.0.0"}, dependencies["pkg:cargo/tokio@1.35.0"])
+	})
+
+	t.Run("parse Cargo.lock v1 correctly", func(t *testing.T) {
+		contents, err := os.ReadFile("../../integration/test/rust/v1/Cargo.lock")
+		require.NoError(t, err)
+
+		got, err := parseCargoLockfile(contents)
+		require.NoError(t, err)
+
+		components := componentsByPURL(t, got)
+		assert.Equal(t, &[]cdx.Hash{{
+			Algorithm: cdx.HashAlgoSHA256,
+			Value:     "14b6052be84e6b71ab17edffc2eeabf5c2c3ae1fdb464aae35ac50c67a44e1f7",
+		}}, components["pkg:cargo/log@0.4.8"].Hashes)
+		assert.Contains(t, *got.Dependencies, cdx.Dependency{
+			Ref:          "pkg:cargo/log@0.4.8",
+			Dependencies: &[]string{"pkg:cargo/cfg-if@0.1.10"},
+		})
+	})
 }
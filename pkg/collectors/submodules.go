@@ -0,0 +1,129 @@
+package collectors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	fp "path/filepath"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	log "github.com/sirupsen/logrus"
+)
+
+const gitmodulesFileName = ".gitmodules"
+
+// GitSubmodules collects git submodules declared in .gitmodules files.
+type GitSubmodules struct{}
+
+func NewGitSubmodulesCollector() GitSubmodules {
+	return GitSubmodules{}
+}
+
+// MatchLanguageFiles implements LanguageCollector interface.
+func (g GitSubmodules) MatchLanguageFiles(isDir bool, filepath string) bool {
+	return !isDir && fp.Base(filepath) == gitmodulesFileName
+}
+
+// BootstrapLanguageFiles implements LanguageCollector interface.
+func (g GitSubmodules) BootstrapLanguageFiles(_ context.Context, bomRoots []string) []string {
+	return SquashToDirs(bomRoots)
+}
+
+/*
+submoduleCommits opens the git repository the given directory belongs to & returns commits its submodules
+are pinned to at HEAD, keyed by paths relative to the directory. The URL of the origin remote is returned
+as well, it's empty when the repository has no such remote.
+*/
+func submoduleCommits(dir string) (map[string]string, string, error) {
+	repository, err := git.PlainOpenWithOptions(dir, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return nil, "", fmt.Errorf("can't open git repository: %w", err)
+	}
+
+	var superprojectURL string
+	if remote, err := repository.Remote(git.DefaultRemoteName); err == nil && len(remote.Config().URLs) > 0 {
+		superprojectURL = remote.Config().URLs[0]
+	}
+
+	worktree, err := repository.Worktree()
+	if err != nil {
+		return nil, superprojectURL, fmt.Errorf("can't open git worktree: %w", err)
+	}
+	absoluteDir, err := fp.Abs(dir)
+	if err != nil {
+		return nil, superprojectURL, err
+	}
+	prefix, err := fp.Rel(worktree.Filesystem.Root(), absoluteDir)
+	if err != nil {
+		return nil, superprojectURL, err
+	}
+
+	head, err := repository.Head()
+	if err != nil {
+		return nil, superprojectURL, fmt.Errorf("can't resolve HEAD: %w", err)
+	}
+	commit, err := repository.CommitObject(head.Hash())
+	if err != nil {
+		return nil, superprojectURL, fmt.Errorf("can't read HEAD commit: %w", err)
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, superprojectURL, fmt.Errorf("can't read HEAD tree: %w", err)
+	}
+
+	if prefix != "." {
+		if tree, err = tree.Tree(fp.ToSlash(prefix)); err != nil {
+			return nil, superprojectURL, fmt.Errorf("can't find %s in HEAD tree: %w", prefix, err)
+		}
+	}
+
+	commits := make(map[string]string)
+	walker := object.NewTreeWalker(tree, true, nil)
+	defer walker.Close()
+	for {
+		name, entry, err := walker.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, superprojectURL, fmt.Errorf("can't walk HEAD tree: %w", err)
+		}
+		if entry.Mode == filemode.Submodule { // Gitlinks hold commits submodules are pinned to
+			commits[name] = entry.Hash.String()
+		}
+	}
+
+	return commits, superprojectURL, nil
+}
+
+/*
+GenerateBOM implements LanguageCollector interface. Submodules are pinned to commits recorded in the HEAD
+commit of the repository. When the bom root isn't a git checkout, submodules are collected without commits.
+*/
+func (g GitSubmodules) GenerateBOM(_ context.Context, bomRoot string) (*cdx.BOM, error) {
+	contents, err := os.ReadFile(fp.Join(bomRoot, gitmodulesFileName))
+	if err != nil {
+		return nil, fmt.Errorf("can't read %s: %w", gitmodulesFileName, err)
+	}
+
+	commits, superprojectURL, err := submoduleCommits(bomRoot)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"collector":       g,
+			"collection path": bomRoot,
+			"error":           err,
+		}).Debug("can't resolve commits of submodules")
+	}
+
+	return parseGitmodules(contents, commits, superprojectURL)
+}
+
+// String implements LanguageCollector interface.
+func (g GitSubmodules) String() string {
+	return "git submodules collector"
+}
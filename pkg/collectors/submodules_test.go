@@ -0,0 +1,177 @@
+package collectors
+
+import (
+	"context"
+	"os"
+	fp "path/filepath"
+	"testing"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	protoCommit        = "3f786850e387550fdab836ed7e6dc881de23001b"
+	designTokensCommit = "89e6c98d92887913cadf06b2adb97f26cde4849b"
+	opensslCommit      = "2b66fd261ee5c6cfc8de7fa466bab600bcfe4f69"
+)
+
+// initSuperproject creates a git repository committing the .gitmodules file & gitlinks of its submodules.
+func initSuperproject(t *testing.T, gitmodules []byte) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	repository, err := git.PlainInit(dir, false)
+	require.NoError(t, err)
+	_, err = repository.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{"https://github.com/vinted/app.git"}})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(fp.Join(dir, gitmodulesFileName), gitmodules, 0o600))
+
+	store := func(encode func(plumbing.EncodedObject) error) plumbing.Hash {
+		o := repository.Storer.NewEncodedObject()
+		require.NoError(t, encode(o))
+		hash, err := repository.Storer.SetEncodedObject(o)
+		require.NoError(t, err)
+		return hash
+	}
+	tree := func(entries ...object.TreeEntry) plumbing.Hash {
+		return store((&object.Tree{Entries: entries}).Encode)
+	}
+
+	blob := store(func(o plumbing.EncodedObject) error {
+		o.SetType(plumbing.BlobObject)
+		w, err := o.Writer()
+		if err != nil {
+			return err
+		}
+		if _, err = w.Write(gitmodules); err != nil {
+			return err
+		}
+		return w.Close()
+	})
+	root := tree(
+		object.TreeEntry{Name: gitmodulesFileName, Mode: filemode.Regular, Hash: blob},
+		object.TreeEntry{Name: "libs", Mode: filemode.Dir, Hash: tree(
+			object.TreeEntry{Name: "design-tokens", Mode: filemode.Submodule, Hash: plumbing.NewHash(designTokensCommit)},
+			object.TreeEntry{Name: "proto", Mode: filemode.Submodule, Hash: plumbing.NewHash(protoCommit)},
+		)},
+		object.TreeEntry{Name: "third_party", Mode: filemode.Dir, Hash: tree(
+			object.TreeEntry{Name: "openssl", Mode: filemode.Submodule, Hash: plumbing.NewHash(opensslCommit)},
+		)},
+	)
+
+	signature := object.Signature{Name: "sbomsftw", Email: "sbomsftw@example.com", When: time.Now()}
+	commit := store((&object.Commit{Author: signature, Committer: signature, Message: "Add submodules", TreeHash: root}).Encode)
+	require.NoError(t, repository.Storer.SetReference(plumbing.NewHashReference(plumbing.Master, commit)))
+
+	return dir
+}
+
+func TestGitSubmodulesCollector(t *testing.T) {
+	t.Run("match correct package files", func(t *testing.T) {
+		submodulesCollector := GitSubmodules{}
+		assert.True(t, submodulesCollector.MatchLanguageFiles(false, ".gitmodules"))
+		assert.True(t, submodulesCollector.MatchLanguageFiles(false, "/tmp/test-repo/libs/proto/.gitmodules"))
+		assert.False(t, submodulesCollector.MatchLanguageFiles(true, ".gitmodules"))
+		assert.False(t, submodulesCollector.MatchLanguageFiles(false, "/tmp/test-repo/.gitignore"))
+	})
+
+	t.Run("bootstrap language files correctly", func(t *testing.T) {
+		got := GitSubmodules{}.BootstrapLanguageFiles(context.Background(), []string{"/tmp/some-random-dir/.gitmodules"})
+		assert.Equal(t, []string{"/tmp/some-random-dir"}, got)
+	})
+
+	t.Run("implement Stringer correctly", func(t *testing.T) {
+		assert.Equal(t, "git submodules collector", GitSubmodules{}.String())
+	})
+
+	t.Run("collect submodules pinned to commits", func(t *testing.T) {
+		gitmodules, err := os.ReadFile("../../integration/test/submodules/.gitmodules")
+		require.NoError(t, err)
+
+		got, err := GitSubmodules{}.GenerateBOM(context.Background(), initSuperproject(t, gitmodules))
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []string{
+			"pkg:generic/git.example.com/mirrors/openssl@" + opensslCommit,
+			"pkg:github/vinted/design-tokens@" + designTokensCommit,
+			"pkg:github/vinted/proto@" + protoCommit,
+		}, purls(t, got))
+
+		components := componentsByPURL(t, got)
+		proto := components["pkg:github/vinted/proto@"+protoCommit]
+		assert.Equal(t, "vinted", proto.Group)
+		assert.Equal(t, protoCommit, proto.Version)
+		assert.Equal(t, cdx.ScopeRequired, proto.Scope)
+		assert.Equal(t, &[]cdx.Property{
+			{Name: "sbomsftw:git:path", Value: "libs/proto"},
+			{Name: "sbomsftw:git:branch", Value: "main"},
+		}, proto.Properties)
+		assert.Equal(t, &[]cdx.ExternalReference{
+			{Type: cdx.ERTypeVCS, URL: "https://github.com/vinted/proto.git#" + protoCommit},
+		}, proto.ExternalReferences)
+
+		// Relative URLs are resolved against the origin remote
+		assert.Equal(t, &[]cdx.ExternalReference{
+			{Type: cdx.ERTypeVCS, URL: "https://github.com/vinted/design-tokens.git#" + designTokensCommit},
+		}, components["pkg:github/vinted/design-tokens@"+designTokensCommit].ExternalReferences)
+
+		openssl := components["pkg:generic/git.example.com/mirrors/openssl@"+opensslCommit]
+		assert.Empty(t, openssl.Group)
+		assert.Equal(t, &[]cdx.ExternalReference{
+			{Type: cdx.ERTypeVCS, URL: "git@git.example.com:mirrors/openssl.git#" + opensslCommit},
+		}, openssl.ExternalReferences)
+	})
+
+	t.Run("collect submodules outside of git checkouts", func(t *testing.T) {
+		dir := t.TempDir()
+		gitmodules, err := os.ReadFile("../../integration/test/submodules/.gitmodules")
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(fp.Join(dir, gitmodulesFileName), gitmodules, 0o600))
+
+		got, err := GitSubmodules{}.GenerateBOM(context.Background(), dir)
+		require.NoError(t, err)
+
+		components := componentsByPURL(t, got)
+		assert.ElementsMatch(t, []string{
+			"pkg:generic/design-tokens",
+			"pkg:generic/git.example.com/mirrors/openssl",
+			"pkg:github/vinted/proto",
+		}, purls(t, got))
+		assert.Nil(t, components["pkg:generic/design-tokens"].ExternalReferences)
+		assert.Equal(t, &[]cdx.ExternalReference{
+			{Type: cdx.ERTypeVCS, URL: "https://github.com/vinted/proto.git"},
+		}, components["pkg:github/vinted/proto"].ExternalReferences)
+	})
+
+	t.Run("resolve relative submodule URLs", func(t *testing.T) {
+		for parentURL, want := range map[string]string{
+			"https://github.com/acme/app.git":     "https://github.com/acme/lib.git",
+			"https://gitlab.com/other/shared":     "https://gitlab.com/other/lib.git",
+			"git@github.com:acme/app.git":         "ssh://git@github.com/acme/lib.git",
+			"ssh://git@git.acme.com:2222/app.git": "ssh://git@git.acme.com:2222/lib.git",
+		} {
+			got, ok := ResolveSubmoduleURL(parentURL, "../lib.git")
+			require.True(t, ok, parentURL)
+			assert.Equal(t, want, got, parentURL)
+		}
+
+		got, ok := ResolveSubmoduleURL("", "https://example.com/lib.git")
+		assert.True(t, ok)
+		assert.Equal(t, "https://example.com/lib.git", got)
+		_, ok = ResolveSubmoduleURL("", "../lib.git")
+		assert.False(t, ok)
+	})
+
+	t.Run("return an error for malformed .gitmodules", func(t *testing.T) {
+		_, err := parseGitmodules([]byte(`[submodule "proto"`), nil, "")
+		assert.Error(t, err)
+	})
+}
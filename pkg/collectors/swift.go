@@ -0,0 +1,118 @@
+package collectors
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+/*
+swiftPackageResolved represents Package.resolved files. Version 1 nests pins in an object & names
+the repository URL differently, versions 2 & 3 store pins at the top level.
+*/
+type swiftPackageResolved struct {
+	Version int        `json:"version"`
+	Pins    []swiftPin `json:"pins"`
+	Object  struct {
+		Pins []swiftPin `json:"pins"`
+	} `json:"object"`
+}
+
+type swiftPin struct {
+	Identity      string `json:"identity"`
+	Package       string `json:"package"`       // Version 1
+	RepositoryURL string `json:"repositoryURL"` // Version 1
+	Kind          string `json:"kind"`
+	Location      string `json:"location"`
+	State         struct {
+		Branch   string `json:"branch"`
+		Revision string `json:"revision"`
+		Version  string `json:"version"`
+	} `json:"state"`
+}
+
+/*
+swiftPURL formats a pkg:swift Package URL from a source repository URL. The namespace consists of the host &
+the repository path. E.g. given the following input:
+
+	git@github.com:Alamofire/Alamofire.git
+
+this function will return: pkg:swift/github.com/Alamofire/Alamofire@<version>
+*/
+func swiftPURL(repositoryURL, version string) string {
+	location := repositoryURL
+	if _, rest, ok := strings.Cut(location, "://"); ok {
+		location = rest
+	} else if _, rest, ok := strings.Cut(location, "@"); ok { // SCP-like SSH URLs
+		location = strings.Replace(rest, ":", "/", 1)
+	}
+	if _, rest, ok := strings.Cut(location, "@"); ok { // Credentials. E.g. https://user@github.com/...
+		location = rest
+	}
+	location = strings.TrimSuffix(strings.TrimSuffix(location, "/"), ".git")
+
+	namespace, name := "", location
+	if i := strings.LastIndex(location, "/"); i != -1 {
+		namespace, name = location[:i], location[i+1:]
+	}
+
+	return packageURL("swift", namespace, name, version)
+}
+
+/*
+parsePackageResolved converts Package.resolved contents (versions 1, 2 & 3) into pkg:swift components.
+Pins of branches or revisions use the commit as their version. Local packages are skipped.
+*/
+func parsePackageResolved(contents []byte) (*cdx.BOM, error) {
+	var resolved swiftPackageResolved
+	if err := json.Unmarshal(contents, &resolved); err != nil {
+		return nil, fmt.Errorf("can't parse Package.resolved: %w", err)
+	}
+
+	pins := resolved.Pins
+	if resolved.Version == 1 {
+		pins = resolved.Object.Pins
+	}
+
+	components := make([]cdx.Component, 0, len(pins))
+	for _, p := range pins {
+		location := p.Location
+		if location == "" {
+			location = p.RepositoryURL
+		}
+		version := p.State.Version
+		if version == "" {
+			version = p.State.Revision
+		}
+
+		var purl string
+		switch p.Kind {
+		case "localSourceControl", "fileSystem":
+			continue
+		case "registry": // Registry identities are scoped. E.g. mona.LinkedList
+			scope, name, _ := strings.Cut(p.Identity, ".")
+			purl = packageURL("swift", scope, name, version)
+		default:
+			purl = swiftPURL(location, version)
+		}
+
+		name := p.Package
+		if name == "" {
+			name = p.Identity
+		}
+		component := newLibraryComponent(purl, name, version)
+		component.Scope = cdx.ScopeRequired
+		if p.Kind != "registry" {
+			vcsURL := location
+			if p.State.Revision != "" {
+				vcsURL += "#" + p.State.Revision
+			}
+			addExternalReference(&component, cdx.ERTypeVCS, vcsURL)
+		}
+		components = append(components, component)
+	}
+
+	return bomFromComponents(uniqueComponents(components)), nil
+}
@@ -0,0 +1,96 @@
+package collectors
+
+import (
+	"context"
+	"errors"
+	"os"
+	fp "path/filepath"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	log "github.com/sirupsen/logrus"
+)
+
+const terraformLockfileName = ".terraform.lock.hcl"
+
+var errNoTerraformFiles = errors.New("no .terraform.lock.hcl or *.tf files could be parsed")
+
+// Terraform collects providers & modules of Terraform configurations.
+type Terraform struct{}
+
+func NewTerraformCollector() Terraform {
+	return Terraform{}
+}
+
+// MatchLanguageFiles implements LanguageCollector interface.
+func (t Terraform) MatchLanguageFiles(isDir bool, filepath string) bool {
+	if isDir {
+		return false
+	}
+	for _, d := range strings.Split(fp.Dir(filepath), string(os.PathSeparator)) {
+		if d == ".terraform" { // Ignore downloaded providers & modules
+			return false
+		}
+	}
+	filename := fp.Base(filepath)
+
+	return filename == terraformLockfileName || fp.Ext(filename) == ".tf"
+}
+
+// BootstrapLanguageFiles implements LanguageCollector interface.
+func (t Terraform) BootstrapLanguageFiles(_ context.Context, bomRoots []string) []string {
+	return SquashToDirs(bomRoots)
+}
+
+/*
+GenerateBOM implements LanguageCollector interface. Providers are read from the dependency lockfile,
+while modules are read from module blocks of every *.tf file in the bom root. Files that can't be
+parsed are skipped.
+*/
+func (t Terraform) GenerateBOM(_ context.Context, bomRoot string) (*cdx.BOM, error) {
+	var (
+		components []cdx.Component
+		parsed     bool
+	)
+	skip := func(filename string, err error) {
+		log.WithFields(log.Fields{
+			"collector":       t,
+			"collection path": bomRoot,
+			"error":           err,
+		}).Debugf("can't parse %s", filename)
+	}
+
+	if contents, err := os.ReadFile(fp.Join(bomRoot, terraformLockfileName)); err == nil {
+		providers, err := parseTerraformLockfile(contents)
+		if err != nil {
+			skip(terraformLockfileName, err)
+		} else {
+			components, parsed = append(components, providers...), true
+		}
+	}
+
+	files, _ := fp.Glob(fp.Join(bomRoot, "*.tf"))
+	for _, f := range files {
+		contents, err := os.ReadFile(f)
+		if err != nil {
+			skip(fp.Base(f), err)
+			continue
+		}
+		modules, err := parseTerraformModules(contents, fp.Base(f))
+		if err != nil {
+			skip(fp.Base(f), err)
+			continue
+		}
+		components, parsed = append(components, modules...), true
+	}
+	if !parsed {
+		return nil, errNoTerraformFiles
+	}
+
+	return bomFromComponents(uniqueComponents(components)), nil
+}
+
+// String implements LanguageCollector interface.
+func (t Terraform) String() string {
+	return "terraform collector"
+}
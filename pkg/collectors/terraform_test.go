@@ -0,0 +1,138 @@
+package collectors
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vinted/sbomsftw/pkg/bomtools"
+)
+
+func TestTerraformCollector(t *testing.T) {
+	t.Run("match correct package files", func(t *testing.T) {
+		terraformCollector := Terraform{}
+		assert.True(t, terraformCollector.MatchLanguageFiles(false, "main.tf"))
+		assert.True(t, terraformCollector.MatchLanguageFiles(false, "/opt/infra/.terraform.lock.hcl"))
+		assert.False(t, terraformCollector.MatchLanguageFiles(false, "/opt/infra/.terraform/modules/vpc/main.tf"))
+		assert.False(t, terraformCollector.MatchLanguageFiles(false, "/opt/infra/terraform.tfvars"))
+		assert.False(t, terraformCollector.MatchLanguageFiles(true, "main.tf"))
+	})
+
+	t.Run("bootstrap language files correctly", func(t *testing.T) {
+		got := Terraform{}.BootstrapLanguageFiles(context.Background(), []string{
+			"/tmp/some-random-dir/main.tf",
+			"/tmp/some-random-dir/.terraform.lock.hcl",
+			"/tmp/some-random-dir/modules/vpc/main.tf",
+		})
+		assert.ElementsMatch(t, []string{"/tmp/some-random-dir", "/tmp/some-random-dir/modules/vpc"}, got)
+	})
+
+	t.Run("collect providers & modules", func(t *testing.T) {
+		got, err := Terraform{}.GenerateBOM(context.Background(), "../../integration/test/terraform")
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []string{
+			"pkg:terraform/hashicorp/aws@5.31.0",
+			"pkg:terraform/integrations/github@5.42.0",
+			"pkg:terraform/terraform.acme.com/acme/internal@1.0.3",
+			"pkg:terraform/terraform-aws-modules/vpc/aws@5.1.2",
+			"pkg:terraform/terraform-aws-modules/eks/aws",
+			"pkg:terraform/git.acme.com/platform/network@v1.4.0#modules/subnets",
+			"pkg:terraform/github.com/cloudposse/terraform-null-label@0.25.0",
+		}, purls(t, got))
+
+		components := componentsByPURL(t, got)
+		aws := components["pkg:terraform/hashicorp/aws@5.31.0"]
+		assert.Equal(t, "hashicorp", aws.Group)
+		assert.Equal(t, &[]cdx.Hash{
+			{Algorithm: cdx.HashAlgoSHA256, Value: "0cdb9c2083bf0902442384f7309367791e4640581652dda456f2d6d7abf0de8d"},
+			{Algorithm: cdx.HashAlgoSHA256, Value: "2fe4884cb9642f48a5889f8dff8f5f511418a18537a9dfa77ada3bcdad391e4e"},
+		}, aws.Hashes)
+		assert.Equal(t, &[]cdx.Property{
+			{Name: "sbomsftw:terraform:kind", Value: "provider"},
+			{Name: "sbomsftw:terraform:constraints", Value: ">= 4.0.0, ~> 5.0"},
+			{Name: "sbomsftw:terraform:hash", Value: "h1:ltxyuBWIy9cq0kIKDJH1jeWJy/y7XJLjS4QrsQK4plA="},
+		}, aws.Properties)
+
+		eks := components["pkg:terraform/terraform-aws-modules/eks/aws"]
+		assert.Equal(t, "eks", eks.Name)
+		assert.Equal(t, &[]cdx.Property{
+			{Name: "sbomsftw:terraform:kind", Value: "module"},
+			{Name: "sbomsftw:terraform:constraints", Value: "~> 19.0"},
+		}, eks.Properties)
+
+		assert.Equal(t, &[]cdx.ExternalReference{{
+			Type: cdx.ERTypeVCS,
+			URL:  "https://git.acme.com/platform/network.git#v1.4.0",
+		}}, components["pkg:terraform/git.acme.com/platform/network@v1.4.0#modules/subnets"].ExternalReferences)
+		assert.Equal(t, &[]cdx.ExternalReference{{
+			Type: cdx.ERTypeVCS,
+			URL:  "https://github.com/cloudposse/terraform-null-label#0.25.0",
+		}}, components["pkg:terraform/github.com/cloudposse/terraform-null-label@0.25.0"].ExternalReferences)
+	})
+
+	t.Run("keep module refs & subdirectories when merging", func(t *testing.T) {
+		infra, err := Terraform{}.GenerateBOM(context.Background(), "../../integration/test/terraform")
+		require.NoError(t, err)
+
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "main.tf"), []byte(`
+module "network" {
+  source = "git::https://git.acme.com/platform/network.git?ref=v1.4.0"
+}
+
+module "label" {
+  source = "github.com/cloudposse/terraform-null-label?ref=0.25.0"
+}
+`), 0o644))
+		staging, err := Terraform{}.GenerateBOM(context.Background(), dir)
+		require.NoError(t, err)
+
+		got, err := bomtools.MergeSBOMs(bomtools.MergeSBOMParam{SBOMs: []*cdx.BOM{infra, staging}})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{
+			"pkg:terraform/hashicorp/aws@5.31.0",
+			"pkg:terraform/integrations/github@5.42.0",
+			"pkg:terraform/terraform.acme.com/acme/internal@1.0.3",
+			"pkg:terraform/terraform-aws-modules/vpc/aws@5.1.2",
+			"pkg:terraform/terraform-aws-modules/eks/aws",
+			"pkg:terraform/git.acme.com/platform/network@v1.4.0",
+			"pkg:terraform/git.acme.com/platform/network@v1.4.0#modules/subnets",
+			"pkg:terraform/github.com/cloudposse/terraform-null-label@0.25.0",
+		}, purls(t, got))
+	})
+
+	t.Run("parse module sources correctly", func(t *testing.T) {
+		for source, want := range map[string]string{
+			"app.terraform.io/acme/vpc/aws":                                  "pkg:terraform/app.terraform.io/acme/vpc/aws@1.0.0",
+			"git@github.com:acme/terraform-modules.git//vpc?ref=v2.0.0":      "pkg:terraform/github.com/acme/terraform-modules@v2.0.0#vpc",
+			"git::ssh://git@git.acme.com/platform/network.git?ref=v1.4.0":    "pkg:terraform/git.acme.com/platform/network@v1.4.0",
+			"bitbucket.org/acme/terraform-modules//vpc?ref=9b1c2d3e4f5a6b7c": "pkg:terraform/bitbucket.org/acme/terraform-modules@9b1c2d3e4f5a6b7c#vpc",
+		} {
+			component, ok := terraformModuleComponent(source, "1.0.0")
+			require.True(t, ok, source)
+			assert.Equal(t, want, component.PackageURL, source)
+		}
+		for _, source := range []string{"./modules/vpc", "../shared", "s3::https://s3.amazonaws.com/acme/vpc.zip", "https://acme.com/vpc.zip"} {
+			_, ok := terraformModuleComponent(source, "")
+			assert.False(t, ok, source)
+		}
+	})
+
+	t.Run("return an error for malformed Terraform files", func(t *testing.T) {
+		_, err := parseTerraformLockfile([]byte(`provider "registry.terraform.io/hashicorp/aws" {}`))
+		assert.Error(t, err)
+		_, err = parseTerraformModules([]byte("module \"vpc\" {\n  source = \n"), "main.tf")
+		assert.Error(t, err)
+		_, err = Terraform{}.GenerateBOM(context.Background(), "/tmp/some-random-dir")
+		assert.ErrorIs(t, err, errNoTerraformFiles)
+	})
+
+	t.Run("implement Stringer correctly", func(t *testing.T) {
+		assert.Equal(t, "terraform collector", Terraform{}.String())
+	})
+}
@@ -0,0 +1,220 @@
+package collectors
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	log "github.com/sirupsen/logrus"
+)
+
+// Hostname of the public Terraform registry, omitted from Package URLs.
+const terraformRegistry = "registry.terraform.io"
+
+// terraformLockfile represents .terraform.lock.hcl files, written by terraform init.
+type terraformLockfile struct {
+	Providers []struct {
+		Address     string   `hcl:"address,label"`
+		Version     string   `hcl:"version"`
+		Constraints string   `hcl:"constraints,optional"`
+		Hashes      []string `hcl:"hashes,optional"`
+	} `hcl:"provider,block"`
+}
+
+// Schema of module blocks. Other module arguments are input variables & meta-arguments, they're ignored.
+var terraformModuleSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{{Type: "module", LabelNames: []string{"name"}}},
+}
+
+var terraformModuleBlockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{{Name: "source", Required: true}, {Name: "version"}},
+}
+
+/*
+terraformPURL creates a pkg:terraform Package URL from a registry address. E.g. given the following input:
+
+	registry.terraform.io/hashicorp/aws
+
+this function will return pkg:terraform/hashicorp/aws. Addresses of private registries keep their hostname.
+*/
+func terraformPURL(address, version string) string {
+	address = strings.TrimPrefix(address, terraformRegistry+"/")
+	segments := strings.Split(address, "/")
+
+	return packageURL("terraform", strings.Join(segments[:len(segments)-1], "/"), segments[len(segments)-1], version)
+}
+
+/*
+parseTerraformLockfile converts providers locked in .terraform.lock.hcl files into pkg:terraform components.
+zh: hashes are SHA-256 checksums of provider packages, so they are recorded as component hashes. h1: hashes
+cover unpacked package contents instead, those are recorded as properties alongside version constraints.
+*/
+func parseTerraformLockfile(contents []byte) ([]cdx.Component, error) {
+	file, diagnostics := hclparse.NewParser().ParseHCL(contents, ".terraform.lock.hcl")
+	if diagnostics.HasErrors() {
+		return nil, fmt.Errorf("can't parse .terraform.lock.hcl: %w", diagnostics)
+	}
+	var lockfile terraformLockfile
+	if diagnostics = gohcl.DecodeBody(file.Body, nil, &lockfile); diagnostics.HasErrors() {
+		return nil, fmt.Errorf("can't parse .terraform.lock.hcl: %w", diagnostics)
+	}
+
+	components := make([]cdx.Component, 0, len(lockfile.Providers))
+	for _, p := range lockfile.Providers {
+		segments := strings.Split(p.Address, "/")
+		component := newLibraryComponent(terraformPURL(p.Address, p.Version), segments[len(segments)-1], p.Version)
+		component.Group = strings.Join(segments[1:len(segments)-1], "/")
+		component.Scope = cdx.ScopeRequired
+		addProperty(&component, "terraform:kind", "provider")
+		addProperty(&component, "terraform:constraints", p.Constraints)
+
+		for _, h := range p.Hashes {
+			scheme, digest, _ := strings.Cut(h, ":")
+			switch scheme {
+			case "zh":
+				addHashes(&component, cdx.Hash{Algorithm: cdx.HashAlgoSHA256, Value: digest})
+			case "h1":
+				addProperty(&component, "terraform:hash", h)
+			}
+		}
+		components = append(components, component)
+	}
+
+	return components, nil
+}
+
+/*
+terraformModuleVersion pins a module version constraint. Only exact versions can be pinned, so
+an empty string is returned for ranges. E.g. given the following input:
+
+	= 5.1.2
+
+this function will return: 5.1.2, while for ~> 5.1 an empty string is returned.
+*/
+func terraformModuleVersion(constraint string) string {
+	version := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(constraint), "="))
+	if strings.ContainsAny(version, "<>~!=, ") {
+		return ""
+	}
+
+	return version
+}
+
+/*
+terraformModuleComponent converts a module source into a pkg:terraform component. Registry modules are
+addressed as [hostname/]namespace/name/provider, while git sources come in many flavours. E.g.
+
+	terraform-aws-modules/vpc/aws
+	git::https://example.com/network.git//modules/vpc?ref=v1.2.0
+	github.com/acme/terraform-modules//vpc?ref=v1.2.0
+
+Git modules are identified by their repository & pinned to the ref. Local paths & other
+sources (archives, buckets) are skipped.
+*/
+func terraformModuleComponent(source, version string) (cdx.Component, bool) {
+	source, query, _ := strings.Cut(strings.TrimPrefix(source, "git::"), "?")
+	values, _ := url.ParseQuery(query)
+
+	// Subdirectories follow a double slash, which mustn't be confused with the one following the scheme
+	var scheme, subdir string
+	if i := strings.Index(source, "://"); i != -1 {
+		scheme, source = source[:i+3], source[i+3:]
+	}
+	source, subdir, _ = strings.Cut(source, "//")
+	source = scheme + source
+
+	var (
+		address, vcsURL string
+		git             bool
+	)
+	switch segments := strings.Split(source, "/"); {
+	case strings.HasPrefix(source, "."):
+		return cdx.Component{}, false
+	case strings.HasPrefix(source, "git@"): // git@github.com:acme/terraform-modules.git
+		address = strings.TrimSuffix(strings.Replace(strings.TrimPrefix(source, "git@"), ":", "/", 1), ".git")
+		vcsURL, git = source, true
+	case strings.Contains(source, "://"):
+		u, err := url.Parse(source)
+		if err != nil || (!strings.HasSuffix(u.Path, ".git") && u.Host != "github.com" && u.Host != "bitbucket.org") {
+			return cdx.Component{}, false
+		}
+		address = u.Host + strings.TrimSuffix(u.Path, ".git")
+		vcsURL, git = source, true
+	case len(segments) >= 3 && (segments[0] == "github.com" || segments[0] == "bitbucket.org"):
+		address = strings.TrimSuffix(source, ".git")
+		vcsURL, git = "https://"+source, true
+	case len(segments) == 3 || (len(segments) == 4 && strings.Contains(segments[0], ".")):
+		address = source
+	default:
+		return cdx.Component{}, false
+	}
+
+	if git {
+		version = values.Get("ref")
+	} else {
+		version = terraformModuleVersion(version)
+	}
+	segments := strings.Split(address, "/")
+	purl := terraformPURL(address, version)
+	if subdir != "" {
+		purl += "#" + subdir
+	}
+
+	name := segments[len(segments)-1]
+	if !git {
+		name = segments[len(segments)-2] // Registry addresses end with the target provider
+	}
+	component := newLibraryComponent(purl, name, version)
+	component.Scope = cdx.ScopeRequired
+	addProperty(&component, "terraform:kind", "module")
+	if git {
+		if version != "" {
+			vcsURL += "#" + version
+		}
+		addExternalReference(&component, cdx.ERTypeVCS, vcsURL)
+	}
+
+	return component, true
+}
+
+// parseTerraformModules converts sources of module blocks declared in a *.tf file into pkg:terraform components.
+func parseTerraformModules(contents []byte, filename string) ([]cdx.Component, error) {
+	file, diagnostics := hclparse.NewParser().ParseHCL(contents, filename)
+	if diagnostics.HasErrors() {
+		return nil, fmt.Errorf("can't parse %s: %w", filename, diagnostics)
+	}
+	content, _, diagnostics := file.Body.PartialContent(terraformModuleSchema)
+	if diagnostics.HasErrors() {
+		return nil, fmt.Errorf("can't parse %s: %w", filename, diagnostics)
+	}
+
+	var components []cdx.Component
+	for _, block := range content.Blocks {
+		module, _, diagnostics := block.Body.PartialContent(terraformModuleBlockSchema)
+		if diagnostics.HasErrors() {
+			return nil, fmt.Errorf("can't parse %s: %w", filename, diagnostics)
+		}
+
+		var source, version string
+		if diagnostics = gohcl.DecodeExpression(module.Attributes["source"].Expr, nil, &source); diagnostics.HasErrors() {
+			log.WithField("module", block.Labels[0]).Debug("skipping module with a non-literal source")
+			continue
+		}
+		if attribute, ok := module.Attributes["version"]; ok {
+			_ = gohcl.DecodeExpression(attribute.Expr, nil, &version)
+		}
+
+		component, ok := terraformModuleComponent(source, version)
+		if !ok {
+			continue
+		}
+		addProperty(&component, "terraform:constraints", strings.TrimSpace(version))
+		components = append(components, component)
+	}
+
+	return components, nil
+}
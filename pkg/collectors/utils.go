@@ -1,6 +1,9 @@
 package collectors
 
-import "path/filepath"
+import (
+	"path/filepath"
+	"sort"
+)
 
 /*
 SquashToDirs - Squash file-paths to directories.
@@ -67,3 +70,14 @@ func SplitPaths(bomRoots []string) map[string][]string {
 
 	return dirsToFiles
 }
+
+// sortedKeys returns keys of a map in ascending order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,170 @@
+package collectors
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/vinted/sbomsftw/pkg/bomtools"
+)
+
+const pypiSimpleIndex = "https://pypi.org/simple"
+
+// uvLockfile represents uv.lock files, written by uv lock.
+type uvLockfile struct {
+	Packages []uvPackage `toml:"package"`
+}
+
+type uvPackage struct {
+	Name    string `toml:"name"`
+	Version string `toml:"version"`
+	Source  struct {
+		Registry  string `toml:"registry"`
+		Git       string `toml:"git"`
+		URL       string `toml:"url"`
+		Editable  string `toml:"editable"`
+		Virtual   string `toml:"virtual"`
+		Directory string `toml:"directory"`
+		Path      string `toml:"path"`
+	} `toml:"source"`
+	Dependencies         []uvDependency            `toml:"dependencies"`
+	OptionalDependencies map[string][]uvDependency `toml:"optional-dependencies"`
+	DevDependencies      map[string][]uvDependency `toml:"dev-dependencies"`
+	Sdist                *struct {
+		Hash string `toml:"hash"`
+	} `toml:"sdist"`
+	Wheels []struct {
+		Hash string `toml:"hash"`
+	} `toml:"wheels"`
+}
+
+// uvDependency references a locked package. Versions are recorded only when several versions of the package are locked.
+type uvDependency struct {
+	Name    string   `toml:"name"`
+	Version string   `toml:"version"`
+	Extra   []string `toml:"extra"`
+}
+
+// local reports whether the package is a workspace member or a local directory or file, those aren't components.
+func (p uvPackage) local() bool {
+	return p.Source.Editable != "" || p.Source.Virtual != "" || p.Source.Directory != "" || p.Source.Path != ""
+}
+
+/*
+uvScopes walks the dependency graph of a uv.lock file from its workspace members & returns scopes of
+locked packages, keyed by their indices. Packages reachable through dependencies of workspace members are
+required, while packages reachable only through optional dependencies (extras) or dependency groups are optional.
+*/
+func uvScopes(packages []uvPackage) map[int]cdx.Scope {
+	resolve := func(d uvDependency) (int, bool) {
+		for i, p := range packages {
+			if bomtools.NormalizePyPIName(p.Name) == bomtools.NormalizePyPIName(d.Name) && (d.Version == "" || p.Version == d.Version) {
+				return i, true
+			}
+		}
+
+		return 0, false
+	}
+
+	type packageExtra struct {
+		index int
+		extra string
+	}
+
+	scopes := make(map[int]cdx.Scope)
+	visitedExtras := make(map[packageExtra]bool) // Extras may depend on each other in cycles. E.g. a[x] -> b[y] -> a[x]
+	var visit func(dependencies []uvDependency, scope cdx.Scope)
+	visit = func(dependencies []uvDependency, scope cdx.Scope) {
+		for _, d := range dependencies {
+			i, ok := resolve(d)
+			if !ok {
+				continue
+			}
+			// Extras are visited even for visited packages, as they may have been visited without those extras
+			for _, extra := range d.Extra {
+				if visitedExtras[packageExtra{index: i, extra: extra}] {
+					continue
+				}
+				visitedExtras[packageExtra{index: i, extra: extra}] = true
+				visit(packages[i].OptionalDependencies[extra], scope)
+			}
+			if _, visited := scopes[i]; visited {
+				continue
+			}
+			scopes[i] = scope
+			visit(packages[i].Dependencies, scope)
+		}
+	}
+
+	// Required packages must be visited first, so that they aren't marked as optional
+	var workspaceMembers []uvPackage
+	for _, p := range packages {
+		if p.Source.Editable != "" || p.Source.Virtual != "" {
+			workspaceMembers = append(workspaceMembers, p)
+		}
+	}
+	for _, m := range workspaceMembers {
+		visit(m.Dependencies, cdx.ScopeRequired)
+	}
+	for _, m := range workspaceMembers {
+		for _, extra := range sortedKeys(m.OptionalDependencies) {
+			visit(m.OptionalDependencies[extra], cdx.ScopeOptional)
+		}
+		for _, group := range sortedKeys(m.DevDependencies) {
+			visit(m.DevDependencies[group], cdx.ScopeOptional)
+		}
+	}
+
+	return scopes
+}
+
+/*
+parseUvLockfile converts uv.lock contents into pkg:pypi components. Scopes are derived from the dependency
+graph, packages unreachable from workspace members default to required. Hashes of source distributions
+& wheels are recorded as component hashes. Workspace members & local packages are skipped.
+*/
+func parseUvLockfile(contents []byte) (*cdx.BOM, error) {
+	var lockfile uvLockfile
+	if _, err := toml.Decode(string(contents), &lockfile); err != nil {
+		return nil, fmt.Errorf("can't parse uv.lock: %w", err)
+	}
+
+	scopes := uvScopes(lockfile.Packages)
+	components := make([]cdx.Component, 0, len(lockfile.Packages))
+	for i, p := range lockfile.Packages {
+		if p.local() {
+			continue
+		}
+
+		component := newLibraryComponent(pypiPURL(p.Name, p.Version), p.Name, p.Version)
+		component.Scope = cdx.ScopeRequired
+		if scope, ok := scopes[i]; ok {
+			component.Scope = scope
+		}
+
+		var digests []string
+		if p.Sdist != nil {
+			digests = append(digests, p.Sdist.Hash)
+		}
+		for _, w := range p.Wheels {
+			digests = append(digests, w.Hash)
+		}
+		addHashes(&component, hashesFromPyPIDigests(digests)...)
+
+		switch {
+		case p.Source.Git != "":
+			// E.g. https://github.com/acme/mylib?rev=main#2ce9e6ed8a7e4d1d4ef5c8d1c3e4b5a6f7e8d9c0
+			repository, commit, _ := strings.Cut(p.Source.Git, "#")
+			repository, _, _ = strings.Cut(repository, "?")
+			addExternalReference(&component, cdx.ERTypeVCS, repository+"#"+commit)
+		case p.Source.URL != "":
+			addExternalReference(&component, cdx.ERTypeDistribution, p.Source.URL)
+		case p.Source.Registry != "" && strings.TrimSuffix(p.Source.Registry, "/") != pypiSimpleIndex:
+			addExternalReference(&component, cdx.ERTypeDistribution, p.Source.Registry)
+		}
+		components = append(components, component)
+	}
+
+	return bomFromComponents(uniqueComponents(components)), nil
+}
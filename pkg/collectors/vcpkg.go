@@ -0,0 +1,115 @@
+package collectors
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+// vcpkgManifest represents vcpkg.json manifest files.
+type vcpkgManifest struct {
+	Dependencies  []vcpkgDependency `json:"dependencies"`
+	Overrides     []vcpkgDependency `json:"overrides"`
+	Baseline      string            `json:"builtin-baseline"`
+	Configuration *vcpkgConfig      `json:"vcpkg-configuration"`
+}
+
+// vcpkgDependency is either a plain port name or an object describing the port.
+type vcpkgDependency struct {
+	Name       string `json:"name"`
+	Version    string `json:"version"`
+	MinVersion string `json:"version>="`
+	Host       bool   `json:"host"`
+	Platform   string `json:"platform"`
+}
+
+func (d *vcpkgDependency) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &d.Name); err == nil {
+		return nil
+	}
+	type plain vcpkgDependency
+
+	return json.Unmarshal(data, (*plain)(d))
+}
+
+// vcpkgConfig represents vcpkg-configuration.json files, which can also be embedded into vcpkg.json.
+type vcpkgConfig struct {
+	DefaultRegistry *vcpkgRegistry  `json:"default-registry"`
+	Registries      []vcpkgRegistry `json:"registries"`
+}
+
+type vcpkgRegistry struct {
+	Kind       string   `json:"kind"`
+	Repository string   `json:"repository"`
+	Baseline   string   `json:"baseline"`
+	Packages   []string `json:"packages"`
+}
+
+// registry finds the registry the port is resolved from. Registries list ports by name or by glob patterns.
+func (c *vcpkgConfig) registry(port string) *vcpkgRegistry {
+	if c == nil {
+		return nil
+	}
+	for i, r := range c.Registries {
+		for _, pattern := range r.Packages {
+			if matched, _ := path.Match(pattern, port); matched {
+				return &c.Registries[i]
+			}
+		}
+	}
+
+	return c.DefaultRegistry
+}
+
+/*
+parseVcpkgManifest converts dependencies of vcpkg.json manifests into pkg:generic components. vcpkg resolves
+port versions from registry baselines, so only versions pinned with overrides are known up front - other
+components are left without a version. The baseline & the registry each port is resolved from are recorded
+as properties instead. Configuration embedded into the manifest is used when vcpkg-configuration.json is missing.
+*/
+func parseVcpkgManifest(manifestContents, configurationContents []byte) (*cdx.BOM, error) {
+	var manifest vcpkgManifest
+	if err := json.Unmarshal(manifestContents, &manifest); err != nil {
+		return nil, fmt.Errorf("can't parse vcpkg.json: %w", err)
+	}
+	configuration := manifest.Configuration
+	if configurationContents != nil {
+		configuration = new(vcpkgConfig)
+		if err := json.Unmarshal(configurationContents, configuration); err != nil {
+			return nil, fmt.Errorf("can't parse vcpkg-configuration.json: %w", err)
+		}
+	}
+
+	overrides := make(map[string]string, len(manifest.Overrides))
+	for _, o := range manifest.Overrides {
+		overrides[o.Name] = o.Version
+	}
+
+	components := make([]cdx.Component, 0, len(manifest.Dependencies))
+	for _, d := range manifest.Dependencies {
+		name, version := strings.ToLower(d.Name), overrides[d.Name]
+		component := newLibraryComponent(packageURL("generic", "", name, version), name, version)
+		component.Scope = cdx.ScopeRequired
+		if d.Host { // Host dependencies are build tools
+			component.Scope = cdx.ScopeExcluded
+		}
+
+		baseline, registry := manifest.Baseline, "builtin"
+		if r := configuration.registry(d.Name); r != nil {
+			baseline, registry = r.Baseline, r.Kind
+			if r.Repository != "" {
+				registry = r.Repository
+			}
+		}
+		addProperty(&component, "vcpkg:baseline", baseline)
+		addProperty(&component, "vcpkg:registry", registry)
+		addProperty(&component, "vcpkg:minimumVersion", d.MinVersion)
+		addProperty(&component, "vcpkg:platform", d.Platform)
+		components = append(components, component)
+	}
+
+	return bomFromComponents(uniqueComponents(components)), nil
+}
@@ -0,0 +1,96 @@
+package collectors
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"gopkg.in/yaml.v3"
+)
+
+// Full length git commit SHAs. Any other git ref (tags, branches, short SHAs) is mutable or ambiguous.
+var commitSHAPattern = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+/*
+workflowReferences walks a workflow or an action.yml file & returns every action, reusable workflow
+& Docker image it references. References are found under uses keys of jobs & steps, as well as
+under the image key of Docker container actions.
+*/
+func workflowReferences(node *yaml.Node) []string {
+	var references []string
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, n := range node.Content {
+			references = append(references, workflowReferences(n)...)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i].Value, node.Content[i+1]
+			if value.Kind == yaml.ScalarNode && (key == "uses" || (key == "image" && strings.HasPrefix(value.Value, "docker://"))) {
+				references = append(references, strings.TrimSpace(value.Value))
+				continue
+			}
+			references = append(references, workflowReferences(value)...)
+		}
+	}
+
+	return references
+}
+
+/*
+actionComponent converts a uses reference into a component. Actions & reusable workflows are referenced as
+owner/repository[/path]@ref & become pkg:githubactions components, where the path is the subpath.
+docker:// references become container components. Whether the reference is pinned to a full commit SHA or
+an immutable image digest is recorded as a property. Local actions (./path) are skipped.
+*/
+func actionComponent(reference string) (cdx.Component, bool) {
+	if image, ok := strings.CutPrefix(reference, "docker://"); ok {
+		r := parseDockerImageReference(image)
+		component := r.component()
+		addProperty(&component, "githubactions:pinned", fmt.Sprintf("%t", r.digest != ""))
+		return component, true
+	}
+
+	repository, ref, ok := strings.Cut(reference, "@")
+	segments := strings.Split(repository, "/")
+	if !ok || strings.HasPrefix(reference, ".") || len(segments) < 2 {
+		return cdx.Component{}, false
+	}
+	owner, name, subpath := segments[0], segments[1], strings.Join(segments[2:], "/")
+
+	purl := packageURL("githubactions", owner, name, ref)
+	if subpath != "" {
+		purl += "#" + subpath
+	}
+	component := newLibraryComponent(purl, name, ref)
+	component.Group = owner
+	addExternalReference(&component, cdx.ERTypeVCS, fmt.Sprintf("https://github.com/%s/%s", owner, name))
+	addProperty(&component, "githubactions:pinned", fmt.Sprintf("%t", commitSHAPattern.MatchString(ref)))
+
+	return component, true
+}
+
+/*
+parseWorkflow converts actions, reusable workflows & Docker images referenced by GitHub Actions workflows or
+composite & Docker container action.yml files into components. They're needed only to build the project,
+so components are excluded.
+*/
+func parseWorkflow(contents []byte) (*cdx.BOM, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(contents, &document); err != nil {
+		return nil, fmt.Errorf("can't parse GitHub Actions workflow: %w", err)
+	}
+
+	var components []cdx.Component
+	for _, reference := range workflowReferences(&document) {
+		component, ok := actionComponent(reference)
+		if !ok {
+			continue
+		}
+		component.Scope = cdx.ScopeExcluded
+		components = append(components, component)
+	}
+
+	return bomFromComponents(uniqueComponents(components)), nil
+}
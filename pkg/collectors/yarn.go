@@ -0,0 +1,183 @@
+package collectors
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"gopkg.in/yaml.v3"
+)
+
+// yarnBerryEntry represents a single package entry inside a Yarn 2+ (Berry) lockfile.
+type yarnBerryEntry struct {
+	Version    string `yaml:"version"`
+	Resolution string `yaml:"resolution"`
+	Checksum   string `yaml:"checksum"`
+}
+
+/*
+splitYarnDescriptor splits a yarn descriptor into a package name & a range. E.g. given the following input:
+
+	@babel/code-frame@npm:^7.0.0
+
+this function will return: "@babel/code-frame", "npm:^7.0.0"
+*/
+func splitYarnDescriptor(descriptor string) (string, string) {
+	descriptor = strings.Trim(strings.TrimSpace(descriptor), `"`)
+	if i := strings.Index(descriptor[min(1, len(descriptor)):], "@"); i != -1 {
+		return descriptor[:i+1], descriptor[i+2:]
+	}
+
+	return descriptor, ""
+}
+
+/*
+parseYarnLockfile converts yarn.lock contents into pkg:npm components. Both the Yarn v1 (classic) text format
+and the Yarn 2+ (Berry) YAML format are supported. Multiple ranges that resolve to the same version are
+squashed into a single component. Local workspace, link & portal packages are skipped.
+*/
+func parseYarnLockfile(contents []byte) ([]cdx.Component, error) {
+	if bytes.Contains(contents, []byte("__metadata:")) {
+		return parseYarnBerryLockfile(contents)
+	}
+
+	return parseYarnClassicLockfile(contents)
+}
+
+func parseYarnClassicLockfile(contents []byte) ([]cdx.Component, error) {
+	var (
+		components []cdx.Component
+		name       string
+		local      bool
+		fields     map[string]string
+	)
+
+	flush := func() {
+		if name == "" || local {
+			return
+		}
+		version, resolved := fields["version"], fields["resolved"]
+
+		component := newLibraryComponent(npmPURL(name, version), name, version)
+		integrity := fields["integrity"]
+		hashes := hashesFromSRI(integrity)
+		if registryURL, checksum, ok := strings.Cut(resolved, "#"); ok && !strings.HasPrefix(resolved, "git") {
+			// Registry tarball URLs carry a SHA-1 checksum in their fragment
+			resolved = registryURL
+			if len(hashes) == 0 {
+				hashes = append(hashes, cdx.Hash{Algorithm: cdx.HashAlgoSHA1, Value: checksum})
+			}
+		}
+		addHashes(&component, hashes...)
+
+		refType := cdx.ERTypeDistribution
+		if strings.HasPrefix(resolved, "git") || strings.Contains(resolved, "codeload.github.com") {
+			refType = cdx.ERTypeVCS
+		}
+		addExternalReference(&component, refType, resolved)
+		components = append(components, component)
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		if !strings.HasPrefix(line, " ") { // New entry. E.g. "lodash@^4.17.20", "lodash@^4.17.21":
+			flush()
+			descriptors := strings.Split(strings.TrimSuffix(trimmed, ":"), ",")
+			var versionRange string
+			name, versionRange = splitYarnDescriptor(descriptors[0])
+			local = strings.HasPrefix(versionRange, "file:") || strings.HasPrefix(versionRange, "link:")
+			// Aliased packages. E.g. my-react@npm:react@^18.0.0 - use the real package name
+			if strings.HasPrefix(versionRange, "npm:") {
+				if realName, _ := splitYarnDescriptor(strings.TrimPrefix(versionRange, "npm:")); realName != "" {
+					name = realName
+				}
+			}
+			fields = make(map[string]string)
+			continue
+		}
+
+		if strings.HasPrefix(line, "    ") { // Nested dependencies blocks
+			continue
+		}
+		key, value, ok := strings.Cut(trimmed, " ")
+		if !ok {
+			continue
+		}
+		fields[key] = strings.Trim(value, `"`)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("can't parse yarn.lock: %w", err)
+	}
+	flush()
+
+	return uniqueComponents(components), nil
+}
+
+func parseYarnBerryLockfile(contents []byte) ([]cdx.Component, error) {
+	var entries map[string]yarnBerryEntry
+	if err := yaml.Unmarshal(contents, &entries); err != nil {
+		return nil, fmt.Errorf("can't parse yarn.lock: %w", err)
+	}
+
+	descriptors := make([]string, 0, len(entries))
+	for descriptor := range entries {
+		descriptors = append(descriptors, descriptor)
+	}
+	// Patched packages go first, so that their patch information is kept when squashing duplicates
+	sort.Slice(descriptors, func(i, j int) bool {
+		iPatched := strings.Contains(entries[descriptors[i]].Resolution, "@patch:")
+		jPatched := strings.Contains(entries[descriptors[j]].Resolution, "@patch:")
+		if iPatched != jPatched {
+			return iPatched
+		}
+		return descriptors[i] < descriptors[j]
+	})
+
+	var components []cdx.Component
+	for _, descriptor := range descriptors {
+		entry := entries[descriptor]
+		if descriptor == "__metadata" || entry.Resolution == "" {
+			continue
+		}
+
+		name, reference := splitYarnDescriptor(entry.Resolution)
+		patched := ""
+		if strings.HasPrefix(reference, "patch:") {
+			// E.g. resolve@patch:resolve@npm%3A1.22.1#~builtin<compat/resolve>::version=1.22.1&hash=07638b
+			source, _, _ := strings.Cut(strings.TrimPrefix(reference, "patch:"), "#")
+			if unescaped, err := url.PathUnescape(source); err == nil {
+				source = unescaped
+			}
+			patched = reference
+			name, reference = splitYarnDescriptor(source)
+		}
+
+		protocol, _, _ := strings.Cut(reference, ":")
+		switch protocol {
+		case "workspace", "link", "portal", "file":
+			continue
+		}
+
+		version := entry.Version
+		component := newLibraryComponent(npmPURL(name, version), name, version)
+		// Checksums cover archives of the Yarn cache, not npm tarballs, so they can't be used as component hashes
+		addProperty(&component, "yarn:checksum", entry.Checksum)
+		if protocol != "npm" && protocol != "" {
+			addExternalReference(&component, cdx.ERTypeVCS, reference)
+		}
+		addProperty(&component, "yarn:patch", patched)
+		components = append(components, component)
+	}
+
+	return uniqueComponents(components), nil
+}
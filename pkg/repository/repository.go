@@ -29,6 +29,20 @@ type Credentials struct {
 	AccessToken string
 }
 
+type options struct {
+	recurseSubmodules bool
+}
+
+// Option configures how the repository is cloned.
+type Option func(options *options)
+
+// WithSubmodules clones submodules of the repository recursively, so their contents are collected as well.
+func WithSubmodules() Option {
+	return func(options *options) {
+		options.recurseSubmodules = true
+	}
+}
+
 type Repository struct {
 	Name               string
 	FSPath             string
@@ -101,7 +115,12 @@ func getHeadReference(vcsURL string, credentials Credentials) (plumbing.Referenc
 New clones the repository supplied in the vcsURL parameter and returns a new Repository instance.
 If repository is private credentials must be supplied.
 */
-func New(ctx context.Context, vcsURL string, credentials Credentials) (*Repository, error) {
+func New(ctx context.Context, vcsURL string, credentials Credentials, opts ...Option) (*Repository, error) {
+	var options options
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	urlPaths := strings.Split(vcsURL, "/")
 	if len(urlPaths) == 0 {
 		return nil, BadVCSURLError{URL: vcsURL}
@@ -135,6 +154,10 @@ func New(ctx context.Context, vcsURL string, credentials Credentials) (*Reposito
 		}
 	}
 
+	if options.recurseSubmodules {
+		updateSubmodules(ctx, vcsURL, clonedRepository, credentials)
+	}
+
 	return &Repository{
 		Name:       name,
 		FSPath:     fsPath,
@@ -145,10 +168,86 @@ func New(ctx context.Context, vcsURL string, credentials Credentials) (*Reposito
 		languageCollectors: []pkg.LanguageCollector{
 			collectors.NewPythonCollector(), collectors.NewRustCollector(), collectors.NewJVMCollector(),
 			collectors.NewGolangCollector(), collectors.NewJSCollector(), collectors.NewRubyCollector(),
+			collectors.NewPHPCollector(), collectors.NewDotNetCollector(), collectors.NewAppleCollector(),
+			collectors.NewDartCollector(), collectors.NewBEAMCollector(), collectors.NewCPPCollector(),
+			collectors.NewDockerCollector(), collectors.NewGitHubActionsCollector(),
+			collectors.NewTerraformCollector(), collectors.NewKubernetesCollector(), collectors.NewGitSubmodulesCollector(),
+			collectors.NewRCollector(), collectors.NewJuliaCollector(), collectors.NewHaskellCollector(),
+			collectors.NewBuildInputsCollector(),
 		},
 	}, nil
 }
 
+/*
+submoduleAuth returns credentials for a submodule only if it's hosted alongside the repository, which the
+credentials were issued for. Submodule URLs must be resolved beforehand, since relative URLs of nested
+submodules may point to a different host than the repository itself.
+*/
+func submoduleAuth(vcsURL, submoduleURL string, credentials Credentials) transport.AuthMethod {
+	if credentials.AccessToken == "" {
+		return nil
+	}
+	repositoryEndpoint, err := transport.NewEndpoint(vcsURL)
+	if err != nil {
+		return nil
+	}
+	endpoint, err := transport.NewEndpoint(submoduleURL)
+	if err != nil || endpoint.Protocol != repositoryEndpoint.Protocol || endpoint.Host != repositoryEndpoint.Host {
+		return nil
+	}
+
+	return &http.BasicAuth{Username: credentials.Username, Password: credentials.AccessToken}
+}
+
+/*
+updateSubmodules clones submodules of the repository recursively. Every level of submodules is cloned separately,
+so that credentials are supplied only to submodules hosted alongside the repository - go-git would pass them
+on to nested submodules otherwise. Submodules that can't be cloned are skipped.
+*/
+func updateSubmodules(ctx context.Context, vcsURL string, repository *git.Repository, credentials Credentials) {
+	var update func(parentURL string, repository *git.Repository, depth int)
+	update = func(parentURL string, repository *git.Repository, depth int) {
+		const errMsgTemplate = "can't clone submodules of %s"
+
+		worktree, err := repository.Worktree()
+		if err != nil {
+			log.WithError(err).Errorf(errMsgTemplate, parentURL) // Not a critical error - log & forget
+			return
+		}
+		submodules, err := worktree.Submodules()
+		if err != nil {
+			log.WithError(err).Errorf(errMsgTemplate, parentURL) // Not a critical error - log & forget
+			return
+		}
+
+		for _, s := range submodules {
+			submoduleURL, ok := collectors.ResolveSubmoduleURL(parentURL, s.Config().URL)
+			if !ok {
+				submoduleURL = s.Config().URL
+			}
+			updateOptions := &git.SubmoduleUpdateOptions{
+				Init:              true,
+				RecurseSubmodules: git.NoRecurseSubmodules,
+				Depth:             1, // Fetch only commits submodules are pinned to
+				Auth:              submoduleAuth(vcsURL, submoduleURL, credentials),
+			}
+
+			if err = s.UpdateContext(ctx, updateOptions); err != nil {
+				log.WithError(err).Errorf("can't clone submodule %s of %s", s.Config().Name, parentURL) // Not a critical error - log & forget
+				continue
+			}
+			if depth >= int(git.DefaultSubmoduleRecursionDepth) {
+				continue
+			}
+			if submoduleRepository, err := s.Repository(); err == nil {
+				update(submoduleURL, submoduleRepository, depth+1)
+			}
+		}
+	}
+
+	update(vcsURL, repository, 1)
+}
+
 func parseCodeOwners(repositoryName string, repository *git.Repository) []string {
 	const errMsgTemplate = "can't parse code owners from %s"
 
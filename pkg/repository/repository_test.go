@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vinted/sbomsftw/pkg/collectors"
+)
+
+func TestSubmoduleCredentials(t *testing.T) {
+	const vcsURL = "https://github.com/acme/app.git"
+	credentials := Credentials{Username: "sbomsftw", AccessToken: "secret"}
+
+	t.Run("supply credentials to submodules hosted alongside the repository", func(t *testing.T) {
+		assert.Equal(t, &http.BasicAuth{Username: "sbomsftw", Password: "secret"},
+			submoduleAuth(vcsURL, "https://github.com/acme/lib.git", credentials))
+		assert.Equal(t, &http.BasicAuth{Username: "sbomsftw", Password: "secret"},
+			submoduleAuth(vcsURL, "https://github.com/acme/shared.git", credentials))
+	})
+
+	t.Run("don't leak credentials to submodules hosted elsewhere", func(t *testing.T) {
+		assert.Nil(t, submoduleAuth(vcsURL, "https://gitlab.com/other/shared.git", credentials))
+		assert.Nil(t, submoduleAuth(vcsURL, "git@github.com:acme/lib.git", credentials))
+
+		// Relative URLs of nested submodules are resolved against their foreign parent
+		nested, ok := collectors.ResolveSubmoduleURL("https://gitlab.com/other/shared.git", "../lib.git")
+		require.True(t, ok)
+		assert.Nil(t, submoduleAuth(vcsURL, nested, credentials))
+	})
+
+	t.Run("supply no credentials without an access token", func(t *testing.T) {
+		assert.Nil(t, submoduleAuth(vcsURL, "https://github.com/acme/lib.git", Credentials{}))
+	})
+}